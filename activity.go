@@ -0,0 +1,116 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap
+
+import "time"
+
+// Clock supplies the current time. It allows tests, and replays of archived
+// alerts, to evaluate alerts at a time other than the present.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is a Clock reading the system time.
+type systemClock struct{}
+
+// Now returns the system time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// fixedClock is a Clock that always reads the same time.
+type fixedClock time.Time
+
+// Now returns the fixed time.
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// SystemClock is a Clock reading the system time.
+var SystemClock Clock = systemClock{}
+
+// FixedClock returns a Clock that always reads t.
+func FixedClock(t time.Time) Clock {
+	return fixedClock(t)
+}
+
+//go:generate stringer -type=Phase -trimprefix=Phase
+
+// Phase is the stage of an Info block at a given time.
+type Phase int
+
+const (
+	// PhasePending :: Before the effective time
+	PhasePending Phase = 0
+	// PhaseImminent :: Effective, but before the onset of the event
+	PhaseImminent Phase = 1
+	// PhaseActive :: Effective and after the onset of the event, if any
+	PhaseActive Phase = 2
+	// PhaseExpired :: At or after the expiry time
+	PhaseExpired Phase = 3
+)
+
+// phase returns the Phase of the Info at time now, given the sent time of its
+// Alert.
+func (t *Info) phase(sent, now time.Time) Phase {
+	if expires := t.Expires.Time(); !expires.IsZero() && !now.Before(expires) {
+		return PhaseExpired
+	}
+	effective := t.Effective.Time()
+	if effective.IsZero() {
+		effective = sent
+	}
+	if now.Before(effective) {
+		return PhasePending
+	}
+	if onset := t.Onset.Time(); now.Before(onset) {
+		return PhaseImminent
+	}
+	return PhaseActive
+}
+
+// Phase returns the Phase of the Info at time now. An Info without an effective
+// time is taken to be effective immediately; use (*Alert).ActiveInfos to have
+// it default to the sent time of the Alert instead. An Info without an expiry
+// time never expires.
+func (t *Info) Phase(now time.Time) Phase {
+	return t.phase(time.Time{}, now)
+}
+
+// ActiveAt returns true if the Info is in effect at time now, that is, if it is
+// PhaseImminent or PhaseActive.
+func (t *Info) ActiveAt(now time.Time) bool {
+	switch t.Phase(now) {
+	case PhaseImminent, PhaseActive:
+		return true
+	}
+	return false
+}
+
+// ActiveInfos returns the Info blocks of the Alert that are in effect at time
+// now. Info blocks without an effective time are effective from the sent time
+// of the Alert. Alerts with a status of Test, Exercise or Draft are never in
+// effect, and nil is returned for them.
+func (t *Alert) ActiveInfos(now time.Time) []*Info {
+	switch t.Status {
+	case StatusTest, StatusExercise, StatusDraft:
+		return nil
+	}
+	var infos []*Info
+	for i := range t.Info {
+		info := &t.Info[i]
+		switch info.phase(t.Sent.Time(), now) {
+		case PhaseImminent, PhaseActive:
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+// InEffect returns true if any Info block of the Alert is in effect at the
+// current time of the clock.
+func (t *Alert) InEffect(clock Clock) bool {
+	return len(t.ActiveInfos(clock.Now())) > 0
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/thetannerryan/cap"
+)
+
+// phasedAlert has an Info with effective, onset and expiry times.
+var phasedAlert = []byte(`<alert xmlns="urn:oasis:names:tc:emergency:cap:1.2">
+	<identifier>phased</identifier>
+	<sent>2019-01-09T02:00:00-00:00</sent>
+	<status>Actual</status>
+	<info>
+		<effective>2019-01-09T03:00:00-00:00</effective>
+		<onset>2019-01-09T04:00:00-00:00</onset>
+		<expires>2019-01-09T05:00:00-00:00</expires>
+	</info>
+</alert>`)
+
+// at returns a time on 2019-01-09 UTC.
+func at(hour, minute int) time.Time {
+	return time.Date(2019, 1, 9, hour, minute, 0, 0, time.UTC)
+}
+
+// TestPhase tests the Phase of an Info over its lifetime.
+func TestPhase(t *testing.T) {
+	alert, err := cap.ParseCAP(phasedAlert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &alert.Info[0]
+	cases := []struct {
+		time   time.Time
+		phase  cap.Phase
+		active bool
+	}{
+		{at(2, 30), cap.PhasePending, false},
+		{at(3, 0), cap.PhaseImminent, true},
+		{at(4, 0), cap.PhaseActive, true},
+		{at(4, 59), cap.PhaseActive, true},
+		{at(5, 0), cap.PhaseExpired, false},
+	}
+	for _, c := range cases {
+		test(t, "Phase at "+c.time.Format("15:04"), c.phase.String(), info.Phase(c.time).String())
+		test(t, "ActiveAt "+c.time.Format("15:04"), fmt.Sprint(c.active), fmt.Sprint(info.ActiveAt(c.time)))
+	}
+
+	alert.Status = cap.StatusExercise
+	test(t, "Exercise active infos", "0", fmt.Sprint(len(alert.ActiveInfos(at(4, 0)))))
+}
+
+// TestActiveInfos tests that Info blocks without an effective time default to
+// the sent time of the Alert.
+func TestActiveInfos(t *testing.T) {
+	contents, err := ioutil.ReadFile("testing/Oasis_ThunderstormWarning.xml")
+	if err != nil {
+		panic(err)
+	}
+	alert, err := cap.ParseCAP(contents)
+	if err != nil {
+		panic(err)
+	}
+	sent := alert.Sent.Time()
+
+	test(t, "Before sent", "0", fmt.Sprint(len(alert.ActiveInfos(sent.Add(-time.Minute)))))
+	test(t, "At sent", "1", fmt.Sprint(len(alert.ActiveInfos(sent))))
+	test(t, "Info alone before sent", "Active", alert.Info[0].Phase(sent.Add(-time.Minute)).String())
+	test(t, "In effect", "true", fmt.Sprint(alert.InEffect(cap.FixedClock(sent.Add(time.Hour-time.Second)))))
+	test(t, "Expired", "false", fmt.Sprint(alert.InEffect(cap.FixedClock(sent.Add(time.Hour+3*time.Minute)))))
+	test(t, "System clock", "false", fmt.Sprint(alert.InEffect(cap.SystemClock)))
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atom_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/thetannerryan/cap/atom"
+)
+
+// test is a helper for the tests.
+func test(t *testing.T, name, expected, actual string) {
+	fmt.Printf(">> Testing %s\nExpected: %s\nActual:   %s\n", name, expected, actual)
+	if expected != actual {
+		t.Errorf("Incorrect output")
+	}
+}
+
+// fixture returns one of the example alerts.
+func fixture(name string) []byte {
+	contents, err := ioutil.ReadFile("../testing/" + name)
+	if err != nil {
+		panic(err)
+	}
+	return contents
+}
+
+// nwsFeed is an Atom index in the style of the US National Weather Service.
+var nwsFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:cap="urn:oasis:names:tc:emergency:cap:1.1">
+<id>https://alerts.example.gov/cap/ca.php</id>
+<title>Current Watches, Warnings and Advisories for California</title>
+<updated>2003-06-17T15:00:00-07:00</updated>
+<entry>
+<id>https://alerts.example.gov/cap/KSTO1055887203</id>
+<updated>2003-06-17T14:57:00-07:00</updated>
+<title>Severe Thunderstorm Warning issued June 17 at 2:57PM PDT</title>
+<link href="https://alerts.example.gov/cap/KSTO1055887203.xml"/>
+<cap:event>Severe Thunderstorm Warning</cap:event>
+<cap:severity>Severe</cap:severity>
+<cap:expires>2003-06-17T16:00:00-07:00</cap:expires>
+<cap:areaDesc>Tuolumne</cap:areaDesc>
+</entry>
+<entry>
+<id>https://alerts.example.gov/cap/TRI13970876</id>
+<updated>2003-06-11T20:56:00-07:00</updated>
+<title>Earthquake Report</title>
+<link rel="related" href="https://example.gov/about"/>
+<link rel="alternate" type="application/cap+xml" href="https://alerts.example.gov/cap/TRI13970876.xml"/>
+<cap:event>Earthquake</cap:event>
+<cap:severity>Minor</cap:severity>
+</entry>
+</feed>`
+
+// rssFeed is an RSS 2.0 index.
+var rssFeed = `<rss version="2.0"><channel>
+<title>MeteoAlarm</title>
+<link>https://feeds.example.eu/</link>
+<item>
+<guid>2.49.0.1.124.3936999913.2019</guid>
+<pubDate>Wed, 09 Jan 2019 02:17:03 +0000</pubDate>
+<title>Wind warning</title>
+<link>https://feeds.example.eu/wind.xml</link>
+</item>
+</channel></rss>`
+
+// TestParse tests parsing Atom and RSS feeds.
+func TestParse(t *testing.T) {
+	feed, err := atom.Parse([]byte(nwsFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Atom title", "Current Watches, Warnings and Advisories for California", feed.Title)
+	test(t, "Atom entries", "2", fmt.Sprint(len(feed.Entries)))
+	entry := feed.Entries[0]
+	test(t, "Atom id", "https://alerts.example.gov/cap/KSTO1055887203", entry.ID)
+	test(t, "Atom updated", "2003-06-17T21:57:00Z", entry.Updated.UTC().Format("2006-01-02T15:04:05Z07:00"))
+	test(t, "Atom link", "https://alerts.example.gov/cap/KSTO1055887203.xml", entry.Link)
+	test(t, "Atom event", "Severe Thunderstorm Warning", entry.Event)
+	test(t, "Atom severity", "Severe", entry.Severity)
+	test(t, "Atom expires", "2003-06-17T23:00:00Z", entry.Expires.UTC().Format("2006-01-02T15:04:05Z07:00"))
+	test(t, "Atom CAP link", "https://alerts.example.gov/cap/TRI13970876.xml", feed.Entries[1].Link)
+	test(t, "Atom no expires", "true", fmt.Sprint(feed.Entries[1].Expires.IsZero()))
+
+	feed, err = atom.Parse([]byte(rssFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry = feed.Entries[0]
+	test(t, "RSS id", "2.49.0.1.124.3936999913.2019", entry.ID)
+	test(t, "RSS updated", "2019-01-09T02:17:03Z", entry.Updated.UTC().Format("2006-01-02T15:04:05Z07:00"))
+	test(t, "RSS link", "https://feeds.example.eu/wind.xml", entry.Link)
+
+	_, err = atom.Parse([]byte("<html></html>"))
+	test(t, "Parse not a feed", "Error: unknown feed element html", fmt.Sprint(err))
+}
+
+// fakeDoer serves canned responses, and records the requests it receives.
+type fakeDoer struct {
+	bodies   map[string]string
+	requests []string
+}
+
+// Do returns the canned response of the request URL.
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	url := req.URL.String()
+	d.requests = append(d.requests, url)
+	resp := &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: ioutil.NopCloser(strings.NewReader(""))}
+	body, ok := d.bodies[url]
+	switch {
+	case !ok:
+		resp.StatusCode = http.StatusNotFound
+	case req.Header.Get("If-None-Match") == `"v1"` && body == nwsFeed:
+		resp.StatusCode = http.StatusNotModified
+	default:
+		resp.Header.Set("ETag", `"v1"`)
+		resp.Body = ioutil.NopCloser(bytes.NewReader([]byte(body)))
+	}
+	return resp, nil
+}
+
+// TestPoller tests that only new or changed entries are resolved.
+func TestPoller(t *testing.T) {
+	doer := &fakeDoer{bodies: map[string]string{
+		"https://alerts.example.gov/feed":                   nwsFeed,
+		"https://alerts.example.gov/cap/KSTO1055887203.xml": string(fixture("Oasis_ThunderstormWarning.xml")),
+	}}
+	poller := atom.NewPoller("https://alerts.example.gov/feed", doer)
+
+	results, err := poller.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "First poll results", "2", fmt.Sprint(len(results)))
+	test(t, "First poll alert", "KSTO1055887203", results[0].Alert.Identifier)
+	test(t, "First poll error", "Error: unexpected status 404 for https://alerts.example.gov/cap/TRI13970876.xml", fmt.Sprint(results[1].Err))
+
+	// the failed entry is retried, without a conditional request
+	doer.bodies["https://alerts.example.gov/cap/TRI13970876.xml"] = string(fixture("Oasis_EarthquakeReport.xml"))
+	doer.requests = nil
+	results, err = poller.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Second poll results", "1", fmt.Sprint(len(results)))
+	test(t, "Second poll alert", "TRI13970876.2", results[0].Alert.Identifier)
+	test(t, "Second poll requests", "[https://alerts.example.gov/feed https://alerts.example.gov/cap/TRI13970876.xml]", fmt.Sprint(doer.requests))
+
+	// the feed is unchanged
+	results, err = poller.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Unchanged poll results", "0", fmt.Sprint(len(results)))
+
+	// an updated entry is resolved again
+	doer.bodies["https://alerts.example.gov/feed"] = strings.Replace(nwsFeed, "<updated>2003-06-17T14:57:00-07:00</updated>", "<updated>2003-06-17T15:10:00-07:00</updated>", 1)
+	results, err = poller.Poll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Changed poll results", "1", fmt.Sprint(len(results)))
+	test(t, "Changed poll entry", "https://alerts.example.gov/cap/KSTO1055887203", results[0].Entry.ID)
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package atom reads and writes the Atom and RSS index feeds through which
+agencies such as the US National Weather Service and MeteoAlarm publish CAP
+alerts.
+
+Parse reads a feed into its entries, including any CAP summary elements (such
+as cap:event and cap:severity) embedded within them. A Poller fetches a feed
+repeatedly, and resolves each new or changed entry to a full *cap.Alert.
+
+A Publisher serves the Atom index of an originator's own active alerts. Expired
+alerts and those cancelled or superseded drop out of the index automatically.
+
+	publisher := atom.NewPublisher("https://alerts.example.gov/feed", "Example Alerts",
+		func(alert *cap.Alert) string {
+			return "https://alerts.example.gov/cap/" + url.PathEscape(alert.Identifier) + ".xml"
+		}, nil)
+	publisher.Publish(alert)
+	http.Handle("/feed", publisher)
+*/
+package atom
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Feed is a parsed Atom or RSS index of CAP alerts.
+type Feed struct {
+	ID      string
+	Title   string
+	Updated time.Time
+	Entries []Entry
+}
+
+// Entry is a single alert within a Feed. CAP summary elements are kept as
+// text, as feeds do not always use CAP 1.2 codes. Times that are absent or
+// cannot be parsed are zero.
+type Entry struct {
+	ID        string    // Atom id or RSS guid
+	Updated   time.Time // Atom updated or RSS pubDate
+	Title     string
+	Link      string // URL of the full CAP document
+	Event     string // cap:event
+	Severity  string // cap:severity
+	Urgency   string // cap:urgency
+	Certainty string // cap:certainty
+	AreaDesc  string // cap:areaDesc
+	Expires   time.Time
+}
+
+// xmlLink is an Atom link element, or the text of an RSS link element.
+type xmlLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// xmlEntry is an Atom entry or RSS item. Elements are matched on their local
+// name, so that CAP summary elements of any CAP namespace are accepted.
+type xmlEntry struct {
+	ID        string    `xml:"id"`
+	GUID      string    `xml:"guid"`
+	Updated   string    `xml:"updated"`
+	PubDate   string    `xml:"pubDate"`
+	Title     string    `xml:"title"`
+	Links     []xmlLink `xml:"link"`
+	Event     string    `xml:"event"`
+	Severity  string    `xml:"severity"`
+	Urgency   string    `xml:"urgency"`
+	Certainty string    `xml:"certainty"`
+	AreaDesc  string    `xml:"areaDesc"`
+	Expires   string    `xml:"expires"`
+}
+
+// xmlFeed is an Atom feed or RSS channel.
+type xmlFeed struct {
+	XMLName xml.Name
+	ID      string     `xml:"id"`
+	Title   string     `xml:"title"`
+	Updated string     `xml:"updated"`
+	Entries []xmlEntry `xml:"entry"`
+	Channel struct {
+		Title         string     `xml:"title"`
+		Link          string     `xml:"link"`
+		LastBuildDate string     `xml:"lastBuildDate"`
+		Items         []xmlEntry `xml:"item"`
+	} `xml:"channel"`
+}
+
+// timeLayouts are the layouts of the times found within feeds.
+var timeLayouts = []string{time.RFC3339, time.RFC1123Z, time.RFC1123, "2006-01-02T15:04:05"}
+
+// parseTime returns the time of a feed element, or the zero time if it cannot
+// be parsed.
+func parseTime(val string) time.Time {
+	val = strings.TrimSpace(val)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, val); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// link returns the URL of the full CAP document of an entry. Links typed as
+// CAP are preferred, followed by alternate links.
+func (e *xmlEntry) link() string {
+	var alternate string
+	for _, link := range e.Links {
+		href := strings.TrimSpace(link.Href)
+		if href == "" {
+			href = strings.TrimSpace(link.Text)
+		}
+		if href == "" {
+			continue
+		}
+		if strings.Contains(link.Type, "cap+xml") {
+			return href
+		}
+		if alternate == "" && (link.Rel == "" || link.Rel == "alternate") {
+			alternate = href
+		}
+	}
+	return alternate
+}
+
+// entry converts the xmlEntry to an Entry.
+func (e *xmlEntry) entry() Entry {
+	entry := Entry{
+		ID:        strings.TrimSpace(e.ID),
+		Updated:   parseTime(e.Updated),
+		Title:     strings.TrimSpace(e.Title),
+		Link:      e.link(),
+		Event:     strings.TrimSpace(e.Event),
+		Severity:  strings.TrimSpace(e.Severity),
+		Urgency:   strings.TrimSpace(e.Urgency),
+		Certainty: strings.TrimSpace(e.Certainty),
+		AreaDesc:  strings.TrimSpace(e.AreaDesc),
+		Expires:   parseTime(e.Expires),
+	}
+	if entry.ID == "" {
+		entry.ID = strings.TrimSpace(e.GUID)
+	}
+	if entry.ID == "" {
+		entry.ID = entry.Link
+	}
+	if entry.Updated.IsZero() {
+		entry.Updated = parseTime(e.PubDate)
+	}
+	return entry
+}
+
+// Parse takes an Atom or RSS 2.0 feed and returns its Feed. If the data is not
+// a feed, an error will be returned.
+func Parse(data []byte) (*Feed, error) {
+	var raw xmlFeed
+	if err := xml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	feed := &Feed{}
+	switch raw.XMLName.Local {
+	case "feed":
+		feed.ID = strings.TrimSpace(raw.ID)
+		feed.Title = strings.TrimSpace(raw.Title)
+		feed.Updated = parseTime(raw.Updated)
+		for i := range raw.Entries {
+			feed.Entries = append(feed.Entries, raw.Entries[i].entry())
+		}
+	case "rss":
+		feed.ID = strings.TrimSpace(raw.Channel.Link)
+		feed.Title = strings.TrimSpace(raw.Channel.Title)
+		feed.Updated = parseTime(raw.Channel.LastBuildDate)
+		for i := range raw.Channel.Items {
+			feed.Entries = append(feed.Entries, raw.Channel.Items[i].entry())
+		}
+	default:
+		return nil, errors.New("Error: unknown feed element " + raw.XMLName.Local)
+	}
+	return feed, nil
+}
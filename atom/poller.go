@@ -0,0 +1,163 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atom
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/thetannerryan/cap"
+)
+
+// maxDocumentSize limits the size of the feeds and CAP documents fetched by a
+// Poller.
+const maxDocumentSize = 16 << 20
+
+// Doer sends HTTP requests. It is satisfied by *http.Client, and may be
+// replaced to add authentication, caching or tests.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Result is an Entry of a Feed resolved to its full Alert. If the Alert could
+// not be fetched or parsed, Err is set and the Entry will be retried by the
+// next Poll.
+type Result struct {
+	Entry Entry
+	Alert *cap.Alert
+	Err   error
+}
+
+// Poller fetches a feed, and resolves each new or changed Entry to its Alert.
+// An Entry is new if its ID has not been seen, and changed if its Updated time
+// differs. A Poller is safe for concurrent use, although Polls are serialized.
+type Poller struct {
+	url    string
+	client Doer
+
+	// Options are used to parse each Alert. They must not be changed while a
+	// Poll is in progress.
+	Options cap.ParseOptions
+
+	mu           sync.Mutex
+	seen         map[string]time.Time
+	etag         string
+	lastModified string
+}
+
+// NewPoller returns a Poller of the feed URL, sending requests with the client.
+// If the client is nil, http.DefaultClient is used.
+func NewPoller(url string, client Doer) *Poller {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Poller{url: url, client: client, seen: make(map[string]time.Time)}
+}
+
+// fetch returns the body of the URL. Conditional request headers are added,
+// and the response headers returned, so that unchanged feeds can be skipped.
+func (p *Poller) fetch(ctx context.Context, url string, header http.Header) ([]byte, http.Header, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	for key, vals := range header {
+		req.Header[key] = vals
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, resp.Header, resp.StatusCode, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, resp.StatusCode, errors.New("Error: unexpected status " + strconv.Itoa(resp.StatusCode) + " for " + url)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxDocumentSize+1))
+	if err != nil {
+		return nil, nil, resp.StatusCode, err
+	}
+	if len(body) > maxDocumentSize {
+		return nil, nil, resp.StatusCode, errors.New("Error: document too large at " + url)
+	}
+	return body, resp.Header, resp.StatusCode, nil
+}
+
+// Poll fetches the feed and returns a Result for each new or changed Entry,
+// in feed order. If the feed is unchanged since the last Poll, no Results are
+// returned. An error is returned only if the feed itself cannot be fetched or
+// parsed.
+func (p *Poller) Poll(ctx context.Context) ([]Result, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	header := make(http.Header)
+	if p.etag != "" {
+		header.Set("If-None-Match", p.etag)
+	}
+	if p.lastModified != "" {
+		header.Set("If-Modified-Since", p.lastModified)
+	}
+	body, respHeader, status, err := p.fetch(ctx, p.url, header)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotModified {
+		return nil, nil
+	}
+	feed, err := Parse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	failed := false
+	current := make(map[string]time.Time, len(feed.Entries))
+	for _, entry := range feed.Entries {
+		if updated, ok := p.seen[entry.ID]; ok && updated.Equal(entry.Updated) {
+			current[entry.ID] = updated
+			continue
+		}
+		result := Result{Entry: entry}
+		result.Alert, result.Err = p.resolve(ctx, entry)
+		if result.Err == nil {
+			current[entry.ID] = entry.Updated
+		} else {
+			failed = true
+		}
+		results = append(results, result)
+	}
+	// entries that have left the feed are forgotten
+	p.seen = current
+	// the validators are kept only once every entry has been resolved, so
+	// that failed entries are retried even if the feed is unchanged
+	p.etag, p.lastModified = "", ""
+	if !failed {
+		p.etag = respHeader.Get("ETag")
+		p.lastModified = respHeader.Get("Last-Modified")
+	}
+	return results, nil
+}
+
+// resolve fetches and parses the Alert of the Entry.
+func (p *Poller) resolve(ctx context.Context, entry Entry) (*cap.Alert, error) {
+	if entry.Link == "" {
+		return nil, errors.New("Error: no link for entry " + entry.ID)
+	}
+	body, _, _, err := p.fetch(ctx, entry.Link, nil)
+	if err != nil {
+		return nil, err
+	}
+	alert, _, err := cap.ParseCAPWithOptions(body, p.Options)
+	return alert, err
+}
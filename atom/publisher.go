@@ -0,0 +1,281 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atom
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thetannerryan/cap"
+)
+
+// Publisher maintains an Atom index of active alerts, in the style used by
+// agencies for aggregator ingestion. Each entry carries CAP summary elements
+// and links to the XML of its alert. Alerts drop out of the index once every
+// Info has expired, or once they are cancelled or superseded. A Publisher is
+// an http.Handler, and is safe for concurrent use.
+type Publisher struct {
+	id       string
+	title    string
+	alertURL func(alert *cap.Alert) string
+	clock    cap.Clock
+
+	mu      sync.Mutex
+	alerts  map[string]*cap.Alert
+	changed time.Time
+}
+
+// NewPublisher returns a Publisher of the feed with the id (an IRI, such as
+// the URL of the feed) and title. The alertURL function returns the URL of the
+// XML of each alert. If the clock is nil, the cap.SystemClock is used.
+func NewPublisher(id, title string, alertURL func(alert *cap.Alert) string, clock cap.Clock) *Publisher {
+	if clock == nil {
+		clock = cap.SystemClock
+	}
+	return &Publisher{
+		id:       id,
+		title:    title,
+		alertURL: alertURL,
+		clock:    clock,
+		alerts:   make(map[string]*cap.Alert),
+		changed:  clock.Now(),
+	}
+}
+
+// publisherKey returns the "sender,identifier" key of an alert.
+func publisherKey(sender, identifier string) string {
+	return sender + "," + identifier
+}
+
+// Publish adds the alert to the index, replacing any alert with the same sender
+// and identifier. The alerts referenced by an Update or Cancel message are
+// removed. Cancel, Ack and Error messages are not themselves listed.
+func (p *Publisher) Publish(alert *cap.Alert) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if alert.MsgType == cap.MsgTypeUpdate || alert.MsgType == cap.MsgTypeCancel {
+		for _, reference := range alert.References.Values() {
+			parts := strings.Split(reference, ",")
+			if len(parts) == 3 {
+				delete(p.alerts, publisherKey(parts[0], parts[1]))
+			}
+		}
+	}
+	switch alert.MsgType {
+	case cap.MsgTypeAlert, cap.MsgTypeUpdate:
+		p.alerts[publisherKey(alert.Sender, alert.Identifier)] = alert
+	}
+	p.changed = p.clock.Now()
+}
+
+// Remove deletes the alert with the sender and identifier from the index. It
+// reports whether such an alert was present.
+func (p *Publisher) Remove(sender, identifier string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := publisherKey(sender, identifier)
+	if _, ok := p.alerts[key]; !ok {
+		return false
+	}
+	delete(p.alerts, key)
+	p.changed = p.clock.Now()
+	return true
+}
+
+// expiry returns the time at which every Info of the alert has expired, or the
+// zero time if the alert does not expire.
+func expiry(alert *cap.Alert) time.Time {
+	var last time.Time
+	for i := range alert.Info {
+		expires := alert.Info[i].Expires.Time()
+		if expires.IsZero() {
+			return time.Time{}
+		}
+		if expires.After(last) {
+			last = expires
+		}
+	}
+	return last
+}
+
+// xmlOutLink is a link element of a published feed.
+type xmlOutLink struct {
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+// xmlOutEntry is an entry element of a published feed.
+type xmlOutEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published"`
+	Author    string     `xml:"author>name"`
+	Link      xmlOutLink `xml:"link"`
+	Summary   string     `xml:"summary,omitempty"`
+
+	Identifier string `xml:"cap:identifier"`
+	Sender     string `xml:"cap:sender"`
+	Sent       string `xml:"cap:sent"`
+	Status     string `xml:"cap:status"`
+	MsgType    string `xml:"cap:msgType"`
+	Scope      string `xml:"cap:scope"`
+	Event      string `xml:"cap:event,omitempty"`
+	Urgency    string `xml:"cap:urgency,omitempty"`
+	Severity   string `xml:"cap:severity,omitempty"`
+	Certainty  string `xml:"cap:certainty,omitempty"`
+	Effective  string `xml:"cap:effective,omitempty"`
+	Expires    string `xml:"cap:expires,omitempty"`
+	AreaDesc   string `xml:"cap:areaDesc,omitempty"`
+}
+
+// xmlOutFeed is a published feed.
+type xmlOutFeed struct {
+	XMLName xml.Name      `xml:"http://www.w3.org/2005/Atom feed"`
+	CAPNS   string        `xml:"xmlns:cap,attr"`
+	ID      string        `xml:"id"`
+	Title   string        `xml:"title"`
+	Updated string        `xml:"updated"`
+	Author  string        `xml:"author>name"`
+	Entries []xmlOutEntry `xml:"entry"`
+}
+
+// dateTime formats an unset DateTime as an empty string.
+func dateTime(t cap.DateTime) string {
+	if t.Time().IsZero() {
+		return ""
+	}
+	return t.String()
+}
+
+// outEntry returns the feed entry of the alert.
+func (p *Publisher) outEntry(alert *cap.Alert) xmlOutEntry {
+	url := p.alertURL(alert)
+	sent := alert.Sent.Time().UTC().Format(time.RFC3339)
+	entry := xmlOutEntry{
+		ID:         url,
+		Title:      alert.Identifier,
+		Updated:    sent,
+		Published:  sent,
+		Author:     alert.Sender,
+		Link:       xmlOutLink{Rel: "alternate", Type: "application/cap+xml", Href: url},
+		Identifier: alert.Identifier,
+		Sender:     alert.Sender,
+		Sent:       alert.Sent.String(),
+		Status:     alert.CodeText(alert.Status),
+		MsgType:    alert.CodeText(alert.MsgType),
+		Scope:      alert.CodeText(alert.Scope),
+	}
+	info := alert.MostSevereInfo()
+	if info == nil {
+		return entry
+	}
+	if headline := strings.TrimSpace(info.Headline); headline != "" {
+		entry.Title = headline
+	} else if event := strings.TrimSpace(info.Event); event != "" {
+		entry.Title = event
+	}
+	if name := strings.TrimSpace(info.SenderName); name != "" {
+		entry.Author = name
+	}
+	entry.Summary = strings.TrimSpace(info.Description)
+	entry.Event = info.Event
+	entry.Urgency = info.CodeText(info.Urgency)
+	entry.Severity = info.CodeText(info.Severity)
+	entry.Certainty = info.CodeText(info.Certainty)
+	entry.Effective = dateTime(info.Effective)
+	entry.Expires = dateTime(info.Expires)
+	var descs []string
+	for _, area := range info.Area {
+		descs = append(descs, area.AreaDesc)
+	}
+	entry.AreaDesc = strings.Join(descs, "; ")
+	return entry
+}
+
+// Render returns the feed at the current time of the clock, with its ETag and
+// last modification time. Expired alerts are removed from the index.
+func (p *Publisher) Render() ([]byte, string, time.Time, error) {
+	now := p.clock.Now()
+	p.mu.Lock()
+	var alerts []*cap.Alert
+	for key, alert := range p.alerts {
+		if expires := expiry(alert); !expires.IsZero() && !now.Before(expires) {
+			delete(p.alerts, key)
+			if expires.After(p.changed) {
+				p.changed = expires
+			}
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+	changed := p.changed.UTC().Truncate(time.Second)
+	p.mu.Unlock()
+
+	// newest first, with ties in a stable order
+	sort.Slice(alerts, func(i, j int) bool {
+		a, b := alerts[i].Sent.Time(), alerts[j].Sent.Time()
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+		return publisherKey(alerts[i].Sender, alerts[i].Identifier) < publisherKey(alerts[j].Sender, alerts[j].Identifier)
+	})
+	feed := xmlOutFeed{
+		CAPNS:   "urn:oasis:names:tc:emergency:cap:1.2",
+		ID:      p.id,
+		Title:   p.title,
+		Updated: changed.Format(time.RFC3339),
+		Author:  p.title,
+	}
+	for _, alert := range alerts {
+		feed.Entries = append(feed.Entries, p.outEntry(alert))
+	}
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, "", time.Time{}, err
+	}
+	body = append([]byte(xml.Header), body...)
+	sum := sha1.Sum(body)
+	return body, `"` + hex.EncodeToString(sum[:]) + `"`, changed, nil
+}
+
+// ServeHTTP serves the feed. Conditional requests with If-None-Match or
+// If-Modified-Since are answered with 304 Not Modified if the feed is
+// unchanged.
+func (p *Publisher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, etag, changed, err := p.Render()
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", changed.Format(http.TimeFormat))
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		if match == etag || match == "*" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	} else if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !changed.After(since) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	if r.Method == http.MethodHead {
+		return
+	}
+	w.Write(body)
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package atom_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/thetannerryan/cap"
+	"github.com/thetannerryan/cap/atom"
+)
+
+// clock is a Clock that can be advanced by the tests.
+type clock struct {
+	now time.Time
+}
+
+// Now returns the current time of the clock.
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+// parseFixture parses one of the example alerts.
+func parseFixture(t *testing.T, name string) *cap.Alert {
+	alert, err := cap.ParseCAP(fixture(name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return alert
+}
+
+// get requests the feed from the handler, with the request headers.
+func get(handler http.Handler, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "https://alerts.example.gov/feed", nil)
+	for key, val := range header {
+		req.Header.Set(key, val)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestPublisher tests publishing alerts, and parsing the published feed.
+func TestPublisher(t *testing.T) {
+	pacific := time.FixedZone("PDT", -7*60*60)
+	c := &clock{now: time.Date(2003, 6, 17, 15, 0, 0, 0, pacific)}
+	publisher := atom.NewPublisher("https://alerts.example.gov/feed", "Example Alerts", func(alert *cap.Alert) string {
+		return "https://alerts.example.gov/cap/" + alert.Identifier + ".xml"
+	}, c)
+	thunderstorm := parseFixture(t, "Oasis_ThunderstormWarning.xml")
+	publisher.Publish(thunderstorm)
+	publisher.Publish(parseFixture(t, "Oasis_EarthquakeReport.xml"))
+
+	rec := get(publisher, nil)
+	test(t, "Status", "200", fmt.Sprint(rec.Code))
+	test(t, "Content type", "application/atom+xml; charset=utf-8", rec.Header().Get("Content-Type"))
+	test(t, "Last modified", "Tue, 17 Jun 2003 22:00:00 GMT", rec.Header().Get("Last-Modified"))
+	feed, err := atom.Parse(rec.Body.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Feed ID", "https://alerts.example.gov/feed", feed.ID)
+	test(t, "Entries", "2", fmt.Sprint(len(feed.Entries)))
+	entry := feed.Entries[0]
+	test(t, "Entry ID", "https://alerts.example.gov/cap/KSTO1055887203.xml", entry.ID)
+	test(t, "Entry title", "SEVERE THUNDERSTORM WARNING", entry.Title)
+	test(t, "Entry link", "https://alerts.example.gov/cap/KSTO1055887203.xml", entry.Link)
+	test(t, "Entry severity", "Severe", entry.Severity)
+	test(t, "Entry expires", "2003-06-17 23:00:00 +0000 UTC", entry.Expires.UTC().String())
+	test(t, "Entry area", "EXTREME NORTH CENTRAL TUOLUMNE COUNTY IN CALIFORNIA, EXTREME NORTHEASTERN CALAVERAS COUNTY IN CALIFORNIA, SOUTHWESTERN ALPINE COUNTY IN CALIFORNIA", entry.AreaDesc)
+	test(t, "Second entry", "https://alerts.example.gov/cap/TRI13970876.2.xml", feed.Entries[1].ID)
+
+	// conditional requests
+	etag := rec.Header().Get("ETag")
+	test(t, "If-None-Match", "304", fmt.Sprint(get(publisher, map[string]string{"If-None-Match": etag}).Code))
+	test(t, "Stale If-None-Match", "200", fmt.Sprint(get(publisher, map[string]string{"If-None-Match": `"stale"`}).Code))
+	test(t, "If-Modified-Since", "304", fmt.Sprint(get(publisher, map[string]string{"If-Modified-Since": "Tue, 17 Jun 2003 22:00:00 GMT"}).Code))
+
+	// the thunderstorm warning drops out once it expires
+	c.now = time.Date(2003, 6, 17, 16, 30, 0, 0, pacific)
+	rec = get(publisher, map[string]string{"If-None-Match": etag})
+	test(t, "Expired status", "200", fmt.Sprint(rec.Code))
+	test(t, "Expired last modified", "Tue, 17 Jun 2003 23:00:00 GMT", rec.Header().Get("Last-Modified"))
+	feed, err = atom.Parse(rec.Body.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Expired entries", "1", fmt.Sprint(len(feed.Entries)))
+
+	// a cancelled alert drops out, and the Cancel is not listed
+	c.now = time.Date(2003, 6, 17, 15, 30, 0, 0, pacific)
+	publisher.Publish(thunderstorm)
+	cancel, err := thunderstorm.NewCancel("Storm has passed").Sent(c.now).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	publisher.Publish(cancel)
+	feed, err = atom.Parse(get(publisher, nil).Body.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Cancelled entries", "1", fmt.Sprint(len(feed.Entries)))
+	test(t, "Cancelled remaining", "https://alerts.example.gov/cap/TRI13970876.2.xml", feed.Entries[0].ID)
+
+	test(t, "Remove", "true", fmt.Sprint(publisher.Remove("trinet@caltech.edu", "TRI13970876.2")))
+	test(t, "Remove missing", "false", fmt.Sprint(publisher.Remove("trinet@caltech.edu", "TRI13970876.2")))
+}
@@ -0,0 +1,359 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap
+
+import "time"
+
+// AlertBuilder composes a new Alert. Its methods return the builder, so that
+// calls may be chained.
+type AlertBuilder struct {
+	alert Alert
+	infos []*InfoBuilder
+}
+
+// InfoBuilder composes an Info block of an AlertBuilder.
+type InfoBuilder struct {
+	info  Info
+	areas []*AreaBuilder
+
+	// whether each required code has been set, as their zero values are
+	// valid codes
+	urgency, severity, certainty bool
+}
+
+// AreaBuilder composes an Area block of an InfoBuilder.
+type AreaBuilder struct {
+	area    Area
+	polygon []Point
+}
+
+// newIdentifier returns an identifier for a new message.
+func newIdentifier() string {
+	return UUIDGenerator{}.NewIdentifier()
+}
+
+// NewAlert returns a builder for an Alert from the sender. The Alert is given a
+// random identifier and is sent now, in the local timezone. Its status, message
+// type and scope default to Actual, Alert and Public.
+func NewAlert(sender string) *AlertBuilder {
+	return &AlertBuilder{alert: Alert{
+		Identifier: newIdentifier(),
+		Sender:     sender,
+		Sent:       NewDateTime(time.Now()),
+		Status:     StatusActual,
+		MsgType:    MsgTypeAlert,
+		Scope:      ScopePublic,
+	}}
+}
+
+// Sender sets the sender of the Alert.
+func (b *AlertBuilder) Sender(sender string) *AlertBuilder {
+	b.alert.Sender = sender
+	return b
+}
+
+// Identifier sets the identifier of the Alert.
+func (b *AlertBuilder) Identifier(identifier string) *AlertBuilder {
+	b.alert.Identifier = identifier
+	return b
+}
+
+// Sent sets the time and date of the origination of the Alert.
+func (b *AlertBuilder) Sent(sent time.Time) *AlertBuilder {
+	b.alert.Sent = NewDateTime(sent)
+	return b
+}
+
+// Status sets the status of the Alert.
+func (b *AlertBuilder) Status(status Status) *AlertBuilder {
+	b.alert.Status = status
+	return b
+}
+
+// MsgType sets the message type of the Alert.
+func (b *AlertBuilder) MsgType(msgType MsgType) *AlertBuilder {
+	b.alert.MsgType = msgType
+	return b
+}
+
+// Source sets the source of the Alert.
+func (b *AlertBuilder) Source(source string) *AlertBuilder {
+	b.alert.Source = source
+	return b
+}
+
+// Scope sets the scope of the Alert.
+func (b *AlertBuilder) Scope(scope Scope) *AlertBuilder {
+	b.alert.Scope = scope
+	return b
+}
+
+// Restriction sets the restriction of an Alert with ScopeRestricted.
+func (b *AlertBuilder) Restriction(restriction string) *AlertBuilder {
+	b.alert.Restriction = restriction
+	return b
+}
+
+// Addresses sets the intended recipients of an Alert with ScopePrivate.
+func (b *AlertBuilder) Addresses(addresses ...string) *AlertBuilder {
+	b.alert.Addresses = NewList(addresses...)
+	return b
+}
+
+// Code adds special handling codes to the Alert.
+func (b *AlertBuilder) Code(codes ...string) *AlertBuilder {
+	b.alert.Code = append(b.alert.Code, codes...)
+	return b
+}
+
+// Note sets the note of the Alert.
+func (b *AlertBuilder) Note(note string) *AlertBuilder {
+	b.alert.Note = note
+	return b
+}
+
+// Incidents sets the incidents referred to by the Alert.
+func (b *AlertBuilder) Incidents(incidents ...string) *AlertBuilder {
+	b.alert.Incidents = NewList(incidents...)
+	return b
+}
+
+// AddInfo adds an Info block to the Alert, and returns its builder. Its
+// urgency, severity and certainty must be set before Build.
+func (b *AlertBuilder) AddInfo() *InfoBuilder {
+	info := &InfoBuilder{}
+	b.infos = append(b.infos, info)
+	return info
+}
+
+// Info returns the builder of the nth (from 0) Info block of the Alert, such as
+// one copied by NewUpdate. If there is no such Info block, nil is returned.
+func (b *AlertBuilder) Info(n int) *InfoBuilder {
+	if n < 0 || n >= len(b.infos) {
+		return nil
+	}
+	return b.infos[n]
+}
+
+// Build returns the composed Alert. If the Alert does not pass Validate, or an
+// Info block was never given its urgency, severity or certainty, the
+// ValidationErrors are returned.
+func (b *AlertBuilder) Build() (*Alert, error) {
+	alert := b.alert
+	alert.Code = append([]string(nil), b.alert.Code...)
+	alert.Info = nil
+	v := &validator{unset: make(map[string]bool)}
+	for i, info := range b.infos {
+		alert.Info = append(alert.Info, info.build())
+		path := index("alert", "info", i)
+		v.unset[path+"/urgency"] = !info.urgency
+		v.unset[path+"/severity"] = !info.severity
+		v.unset[path+"/certainty"] = !info.certainty
+	}
+	if err := alert.validate(v); err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// build returns the composed Info.
+func (b *InfoBuilder) build() Info {
+	info := b.info
+	info.Category = append([]Category(nil), b.info.Category...)
+	info.ResponseType = append([]ResponseType(nil), b.info.ResponseType...)
+	info.EventCode = append([]KeyValue(nil), b.info.EventCode...)
+	info.Parameter = append([]KeyValue(nil), b.info.Parameter...)
+	info.Unrecognized = append([]string(nil), b.info.Unrecognized...)
+	info.Resource = append([]Resource(nil), b.info.Resource...)
+	info.Area = nil
+	for _, area := range b.areas {
+		info.Area = append(info.Area, area.build())
+	}
+	return info
+}
+
+// Language sets the RFC 3066 language of the Info.
+func (b *InfoBuilder) Language(language string) *InfoBuilder {
+	b.info.Language = language
+	return b
+}
+
+// Category adds categories of the subject event.
+func (b *InfoBuilder) Category(categories ...Category) *InfoBuilder {
+	b.info.Category = append(b.info.Category, categories...)
+	return b
+}
+
+// Event sets the type of the subject event.
+func (b *InfoBuilder) Event(event string) *InfoBuilder {
+	b.info.Event = event
+	return b
+}
+
+// ResponseType adds recommended actions for the audience.
+func (b *InfoBuilder) ResponseType(responseTypes ...ResponseType) *InfoBuilder {
+	b.info.ResponseType = append(b.info.ResponseType, responseTypes...)
+	return b
+}
+
+// Urgency sets the urgency of the subject event.
+func (b *InfoBuilder) Urgency(urgency Urgency) *InfoBuilder {
+	b.info.Urgency = urgency
+	b.urgency = true
+	return b
+}
+
+// Severity sets the severity of the subject event.
+func (b *InfoBuilder) Severity(severity Severity) *InfoBuilder {
+	b.info.Severity = severity
+	b.severity = true
+	return b
+}
+
+// Certainty sets the certainty of the subject event.
+func (b *InfoBuilder) Certainty(certainty Certainty) *InfoBuilder {
+	b.info.Certainty = certainty
+	b.certainty = true
+	return b
+}
+
+// Audience sets the intended audience of the Info.
+func (b *InfoBuilder) Audience(audience string) *InfoBuilder {
+	b.info.Audience = audience
+	return b
+}
+
+// EventCode adds a system-specific code identifying the event type.
+func (b *InfoBuilder) EventCode(valueName, value string) *InfoBuilder {
+	b.info.EventCode = append(b.info.EventCode, KeyValue{ValueName: valueName, Value: value})
+	return b
+}
+
+// Effective sets the effective time of the Info.
+func (b *InfoBuilder) Effective(effective time.Time) *InfoBuilder {
+	b.info.Effective = NewDateTime(effective)
+	return b
+}
+
+// Onset sets the expected beginning of the subject event.
+func (b *InfoBuilder) Onset(onset time.Time) *InfoBuilder {
+	b.info.Onset = NewDateTime(onset)
+	return b
+}
+
+// Expires sets the expiry time of the Info.
+func (b *InfoBuilder) Expires(expires time.Time) *InfoBuilder {
+	b.info.Expires = NewDateTime(expires)
+	return b
+}
+
+// SenderName sets the name of the originator of the Info.
+func (b *InfoBuilder) SenderName(senderName string) *InfoBuilder {
+	b.info.SenderName = senderName
+	return b
+}
+
+// Headline sets the headline of the Info.
+func (b *InfoBuilder) Headline(headline string) *InfoBuilder {
+	b.info.Headline = headline
+	return b
+}
+
+// Description sets the description of the subject event.
+func (b *InfoBuilder) Description(description string) *InfoBuilder {
+	b.info.Description = description
+	return b
+}
+
+// Instruction sets the recommended action for recipients.
+func (b *InfoBuilder) Instruction(instruction string) *InfoBuilder {
+	b.info.Instruction = instruction
+	return b
+}
+
+// Web sets the hyperlink to additional information.
+func (b *InfoBuilder) Web(web string) *InfoBuilder {
+	b.info.Web = web
+	return b
+}
+
+// Contact sets the contact for follow-up and confirmation.
+func (b *InfoBuilder) Contact(contact string) *InfoBuilder {
+	b.info.Contact = contact
+	return b
+}
+
+// Parameter adds a system-specific parameter.
+func (b *InfoBuilder) Parameter(valueName, value string) *InfoBuilder {
+	b.info.Parameter = append(b.info.Parameter, KeyValue{ValueName: valueName, Value: value})
+	return b
+}
+
+// AddResource adds a Resource block referring to the URI.
+func (b *InfoBuilder) AddResource(resourceDesc, mimeType, uri string) *InfoBuilder {
+	b.info.Resource = append(b.info.Resource, Resource{
+		ResourceDesc: resourceDesc,
+		MimeType:     mimeType,
+		URI:          uri,
+	})
+	return b
+}
+
+// AddArea adds an Area block with the description to the Info, and returns its
+// builder.
+func (b *InfoBuilder) AddArea(areaDesc string) *AreaBuilder {
+	area := &AreaBuilder{area: Area{AreaDesc: areaDesc}}
+	b.areas = append(b.areas, area)
+	return area
+}
+
+// build returns the composed Area.
+func (b *AreaBuilder) build() Area {
+	area := b.area
+	area.Circle = append([]string(nil), b.area.Circle...)
+	area.Geocode = append([]KeyValue(nil), b.area.Geocode...)
+	if len(b.polygon) > 0 {
+		vals := make([]string, len(b.polygon))
+		for i, point := range b.polygon {
+			vals[i] = point.String()
+		}
+		area.Polygon = NewList(vals...)
+	}
+	return area
+}
+
+// Polygon sets the polygon of the Area. The polygon is closed by repeating the
+// first point, if required.
+func (b *AreaBuilder) Polygon(points []Point) *AreaBuilder {
+	b.polygon = append([]Point(nil), points...)
+	if len(points) > 0 && points[0] != points[len(points)-1] {
+		b.polygon = append(b.polygon, points[0])
+	}
+	return b
+}
+
+// Circle adds a circle with the center and a radius in kilometers to the Area.
+func (b *AreaBuilder) Circle(center Point, radiusKm float64) *AreaBuilder {
+	b.area.Circle = append(b.area.Circle, Circle{Center: center, Radius: radiusKm}.String())
+	return b
+}
+
+// Geocode adds a geographic code to the Area.
+func (b *AreaBuilder) Geocode(valueName, value string) *AreaBuilder {
+	b.area.Geocode = append(b.area.Geocode, KeyValue{ValueName: valueName, Value: value})
+	return b
+}
+
+// Altitude sets the altitude of the Area, in feet above mean sea level.
+func (b *AreaBuilder) Altitude(altitude float32) *AreaBuilder {
+	b.area.Altitude = altitude
+	return b
+}
+
+// Ceiling sets the maximum altitude of the Area, in feet above mean sea level.
+func (b *AreaBuilder) Ceiling(ceiling float32) *AreaBuilder {
+	b.area.Ceiling = ceiling
+	return b
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thetannerryan/cap"
+)
+
+// TestBuilder tests that a built Alert marshals and parses back unchanged.
+func TestBuilder(t *testing.T) {
+	sent := time.Date(2019, 1, 9, 2, 17, 3, 0, time.UTC)
+	builder := cap.NewAlert("cap@example.org").Identifier("example-1").Sent(sent)
+	info := builder.AddInfo().
+		Language("en-CA").
+		Category(cap.CategoryMet).
+		Event("wind").
+		ResponseType(cap.ResponseTypeMonitor).
+		Urgency(cap.UrgencyFuture).
+		Severity(cap.SeverityModerate).
+		Certainty(cap.CertaintyLikely).
+		EventCode("profile:CAP-CP:Event:0.4", "wind").
+		Expires(sent.Add(16 * time.Hour)).
+		Headline("wind warning in effect")
+	info.AddArea("Mont-Tremblant").
+		Polygon([]cap.Point{{Latitude: 46.1, Longitude: -74.6}, {Latitude: 46.3, Longitude: -74.6}, {Latitude: 46.3, Longitude: -74.4}}).
+		Circle(cap.Point{Latitude: 46.2, Longitude: -74.5}, 10).
+		Geocode("profile:CAP-CP:Location:0.3", "2478")
+	alert, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := xml.Marshal(alert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := cap.ParseCAP(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Built identifier", "example-1", parsed.Identifier)
+	test(t, "Built sent", "2019-01-09T02:17:03-00:00", parsed.Sent.String())
+	test(t, "Built status", "Actual", parsed.Status.String())
+	test(t, "Built category", "Met", parsed.Info[0].Category[0].String())
+	test(t, "Built expires", "2019-01-09T18:17:03-00:00", parsed.Info[0].Expires.String())
+	test(t, "Built polygon", "46.1,-74.6 46.3,-74.6 46.3,-74.4 46.1,-74.6", parsed.Info[0].Area[0].Polygon.String())
+	test(t, "Built circle", "46.2,-74.5 10", parsed.Info[0].Area[0].Circle[0])
+
+	generated, err := cap.NewAlert("cap@example.org").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Generated identifier length", "36", fmt.Sprint(len(generated.Identifier)))
+}
+
+// TestValidate tests that the examples are valid, and that problems with a
+// built Alert are reported with their paths.
+func TestValidate(t *testing.T) {
+	for _, name := range []string{
+		"Oasis_AmberAlert.xml",
+		"Oasis_EarthquakeReport.xml",
+		"Oasis_HomelandAlert.xml",
+		"Oasis_ThunderstormWarning.xml",
+		"PelmorexNAADS_WindWarning.xml",
+	} {
+		contents, err := ioutil.ReadFile("testing/" + name)
+		if err != nil {
+			panic(err)
+		}
+		alert, err := cap.ParseCAP(contents)
+		if err != nil {
+			panic(err)
+		}
+		test(t, "Validate "+name, "<nil>", fmt.Sprint(alert.Validate()))
+	}
+
+	builder := cap.NewAlert("cap@example.org").Scope(cap.ScopePrivate)
+	builder.AddInfo().Severity(cap.Severity(42)).AddArea("").Polygon([]cap.Point{{Latitude: 1, Longitude: 1}, {Latitude: 2, Longitude: 2}})
+	_, err := builder.Build()
+	test(t, "Validate errors", "Error: missing required element at alert/addresses; "+
+		"Error: missing required element at alert/info[1]/category; "+
+		"Error: missing required element at alert/info[1]/event; "+
+		"Error: missing required element at alert/info[1]/urgency; "+
+		"Error: illegal value Severity(42) for Severity code at alert/info[1]/severity; "+
+		"Error: missing required element at alert/info[1]/certainty; "+
+		"Error: missing required element at alert/info[1]/area[1]/areaDesc; "+
+		"Error: polygon requires at least four points at alert/info[1]/area[1]/polygon[1]", fmt.Sprint(err))
+}
+
+// TestBuilderRequired tests that an Info block missing any of its required
+// codes or event is not built, rather than taking the zero value codes.
+func TestBuilderRequired(t *testing.T) {
+	for _, missing := range []string{"urgency", "severity", "certainty", "event"} {
+		builder := cap.NewAlert("cap@example.org")
+		info := builder.AddInfo().Category(cap.CategoryMet)
+		if missing != "urgency" {
+			info.Urgency(cap.UrgencyFuture)
+		}
+		if missing != "severity" {
+			info.Severity(cap.SeverityModerate)
+		}
+		if missing != "certainty" {
+			info.Certainty(cap.CertaintyLikely)
+		}
+		if missing != "event" {
+			info.Event("wind")
+		}
+		info.AddArea("x")
+		_, err := builder.Build()
+		test(t, "Missing "+missing, "Error: missing required element at alert/info[1]/"+missing, fmt.Sprint(err))
+	}
+}
+
+// TestBuilderUnset tests that the elements left unset on a built Alert are not
+// written, as empty elements and zero dates are not valid CAP.
+func TestBuilderUnset(t *testing.T) {
+	builder := cap.NewAlert("cap@example.org")
+	builder.AddInfo().
+		Category(cap.CategoryMet).
+		Event("wind").
+		Urgency(cap.UrgencyFuture).
+		Severity(cap.SeverityModerate).
+		Certainty(cap.CertaintyLikely).
+		AddArea("Mont-Tremblant")
+	alert, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := xml.Marshal(alert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Unset empty elements", "[]", fmt.Sprint(regexp.MustCompile(`<[^>/]+></[^>]+>|<[^>]+/>`).FindAllString(string(out), -1)))
+	test(t, "Unset dates", "false", fmt.Sprint(strings.Contains(string(out), "0001-01-01")))
+	test(t, "Unset altitude", "false", fmt.Sprint(strings.Contains(string(out), "<altitude>")))
+	if _, err := cap.ParseCAP(out); err != nil {
+		t.Fatal(err)
+	}
+}
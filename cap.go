@@ -4,17 +4,9 @@
 
 package cap
 
-import (
-	"encoding/xml"
-)
-
 // ParseCAP takes a valid XML byte CAP 1.2 message and returns an Alert. If the
-// message is invalid, an error will be returned.
+// message is invalid, a *ParseError will be returned.
 func ParseCAP(data []byte) (*Alert, error) {
-	var alert Alert
-	err := xml.Unmarshal(data, &alert)
-	if err != nil {
-		return nil, err
-	}
-	return &alert, nil
+	alert, _, err := ParseCAPWithOptions(data, ParseOptions{})
+	return alert, err
 }
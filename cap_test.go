@@ -5,8 +5,11 @@
 package cap_test
 
 import (
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io/ioutil"
+	"strings"
 	"testing"
 
 	"github.com/thetannerryan/cap"
@@ -381,3 +384,16 @@ Please continue to monitor alerts and forecasts issued by Environment Canada. To
 	test(t, "Wind info english area geocode 1 value name", "profile:CAP-CP:Location:0.3", infoEnglishArea.Geocode[1].ValueName)
 	test(t, "Wind info english area geocode 1 value", "2401", infoEnglishArea.Geocode[1].Value)
 }
+
+// TestInvalidCode tests that codes outside of their enumeration are reported
+// by String and rejected when marshaling.
+func TestInvalidCode(t *testing.T) {
+	test(t, "Invalid severity string", "Severity(42)", cap.Severity(42).String())
+	test(t, "Invalid category string", "Category(-1)", cap.Category(-1).String())
+	test(t, "Valid response type string", "AllClear", cap.ResponseTypeAllClear.String())
+
+	_, err := xml.Marshal(cap.Info{Severity: cap.Severity(42)})
+	test(t, "Invalid severity XML", "Error: illegal value Severity(42) for Severity code", fmt.Sprint(err))
+	_, err = json.Marshal(cap.Alert{Status: cap.Status(7)})
+	test(t, "Invalid status JSON", "true", fmt.Sprint(err != nil && strings.HasSuffix(err.Error(), "Error: illegal value Status(7) for Status code")))
+}
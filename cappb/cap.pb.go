@@ -0,0 +1,2092 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Protocol Buffers form of OASIS Common Alerting Protocol Version 1.2 (CAP),
+// including the XML digital signatures of CAP-CP and NAADS.
+//
+// Element names follow CAP 1.2. Optional elements are optional fields, present
+// only if the element is set. dateTime values are kept as their CAP text,
+// preserving the time zone offset and fractional seconds.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: cap.proto
+
+package cappb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Status is the code denoting the appropriate handling of the alert message.
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_ACTUAL      Status = 1
+	Status_STATUS_EXERCISE    Status = 2
+	Status_STATUS_SYSTEM      Status = 3
+	Status_STATUS_TEST        Status = 4
+	Status_STATUS_DRAFT       Status = 5
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_ACTUAL",
+		2: "STATUS_EXERCISE",
+		3: "STATUS_SYSTEM",
+		4: "STATUS_TEST",
+		5: "STATUS_DRAFT",
+	}
+	Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_ACTUAL":      1,
+		"STATUS_EXERCISE":    2,
+		"STATUS_SYSTEM":      3,
+		"STATUS_TEST":        4,
+		"STATUS_DRAFT":       5,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_cap_proto_enumTypes[0].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_cap_proto_enumTypes[0]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Status.Descriptor instead.
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{0}
+}
+
+// MsgType is the code denoting the nature of the alert message.
+type MsgType int32
+
+const (
+	MsgType_MSG_TYPE_UNSPECIFIED MsgType = 0
+	MsgType_MSG_TYPE_ALERT       MsgType = 1
+	MsgType_MSG_TYPE_UPDATE      MsgType = 2
+	MsgType_MSG_TYPE_CANCEL      MsgType = 3
+	MsgType_MSG_TYPE_ACK         MsgType = 4
+	MsgType_MSG_TYPE_ERROR       MsgType = 5
+)
+
+// Enum value maps for MsgType.
+var (
+	MsgType_name = map[int32]string{
+		0: "MSG_TYPE_UNSPECIFIED",
+		1: "MSG_TYPE_ALERT",
+		2: "MSG_TYPE_UPDATE",
+		3: "MSG_TYPE_CANCEL",
+		4: "MSG_TYPE_ACK",
+		5: "MSG_TYPE_ERROR",
+	}
+	MsgType_value = map[string]int32{
+		"MSG_TYPE_UNSPECIFIED": 0,
+		"MSG_TYPE_ALERT":       1,
+		"MSG_TYPE_UPDATE":      2,
+		"MSG_TYPE_CANCEL":      3,
+		"MSG_TYPE_ACK":         4,
+		"MSG_TYPE_ERROR":       5,
+	}
+)
+
+func (x MsgType) Enum() *MsgType {
+	p := new(MsgType)
+	*p = x
+	return p
+}
+
+func (x MsgType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MsgType) Descriptor() protoreflect.EnumDescriptor {
+	return file_cap_proto_enumTypes[1].Descriptor()
+}
+
+func (MsgType) Type() protoreflect.EnumType {
+	return &file_cap_proto_enumTypes[1]
+}
+
+func (x MsgType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MsgType.Descriptor instead.
+func (MsgType) EnumDescriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{1}
+}
+
+// Scope is the code denoting the intended distribution of the alert message.
+type Scope int32
+
+const (
+	Scope_SCOPE_UNSPECIFIED Scope = 0
+	Scope_SCOPE_PUBLIC      Scope = 1
+	Scope_SCOPE_RESTRICTED  Scope = 2
+	Scope_SCOPE_PRIVATE     Scope = 3
+)
+
+// Enum value maps for Scope.
+var (
+	Scope_name = map[int32]string{
+		0: "SCOPE_UNSPECIFIED",
+		1: "SCOPE_PUBLIC",
+		2: "SCOPE_RESTRICTED",
+		3: "SCOPE_PRIVATE",
+	}
+	Scope_value = map[string]int32{
+		"SCOPE_UNSPECIFIED": 0,
+		"SCOPE_PUBLIC":      1,
+		"SCOPE_RESTRICTED":  2,
+		"SCOPE_PRIVATE":     3,
+	}
+)
+
+func (x Scope) Enum() *Scope {
+	p := new(Scope)
+	*p = x
+	return p
+}
+
+func (x Scope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Scope) Descriptor() protoreflect.EnumDescriptor {
+	return file_cap_proto_enumTypes[2].Descriptor()
+}
+
+func (Scope) Type() protoreflect.EnumType {
+	return &file_cap_proto_enumTypes[2]
+}
+
+func (x Scope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Scope.Descriptor instead.
+func (Scope) EnumDescriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{2}
+}
+
+// Category is the code denoting the category of the subject event.
+type Category int32
+
+const (
+	Category_CATEGORY_UNSPECIFIED Category = 0
+	Category_CATEGORY_GEO         Category = 1
+	Category_CATEGORY_MET         Category = 2
+	Category_CATEGORY_SAFETY      Category = 3
+	Category_CATEGORY_SECURITY    Category = 4
+	Category_CATEGORY_RESCUE      Category = 5
+	Category_CATEGORY_FIRE        Category = 6
+	Category_CATEGORY_HEALTH      Category = 7
+	Category_CATEGORY_ENV         Category = 8
+	Category_CATEGORY_TRANSPORT   Category = 9
+	Category_CATEGORY_INFRA       Category = 10
+	Category_CATEGORY_CBRNE       Category = 11
+	Category_CATEGORY_OTHER       Category = 12
+)
+
+// Enum value maps for Category.
+var (
+	Category_name = map[int32]string{
+		0:  "CATEGORY_UNSPECIFIED",
+		1:  "CATEGORY_GEO",
+		2:  "CATEGORY_MET",
+		3:  "CATEGORY_SAFETY",
+		4:  "CATEGORY_SECURITY",
+		5:  "CATEGORY_RESCUE",
+		6:  "CATEGORY_FIRE",
+		7:  "CATEGORY_HEALTH",
+		8:  "CATEGORY_ENV",
+		9:  "CATEGORY_TRANSPORT",
+		10: "CATEGORY_INFRA",
+		11: "CATEGORY_CBRNE",
+		12: "CATEGORY_OTHER",
+	}
+	Category_value = map[string]int32{
+		"CATEGORY_UNSPECIFIED": 0,
+		"CATEGORY_GEO":         1,
+		"CATEGORY_MET":         2,
+		"CATEGORY_SAFETY":      3,
+		"CATEGORY_SECURITY":    4,
+		"CATEGORY_RESCUE":      5,
+		"CATEGORY_FIRE":        6,
+		"CATEGORY_HEALTH":      7,
+		"CATEGORY_ENV":         8,
+		"CATEGORY_TRANSPORT":   9,
+		"CATEGORY_INFRA":       10,
+		"CATEGORY_CBRNE":       11,
+		"CATEGORY_OTHER":       12,
+	}
+)
+
+func (x Category) Enum() *Category {
+	p := new(Category)
+	*p = x
+	return p
+}
+
+func (x Category) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Category) Descriptor() protoreflect.EnumDescriptor {
+	return file_cap_proto_enumTypes[3].Descriptor()
+}
+
+func (Category) Type() protoreflect.EnumType {
+	return &file_cap_proto_enumTypes[3]
+}
+
+func (x Category) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Category.Descriptor instead.
+func (Category) EnumDescriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{3}
+}
+
+// ResponseType is the code denoting the type of action recommended.
+type ResponseType int32
+
+const (
+	ResponseType_RESPONSE_TYPE_UNSPECIFIED ResponseType = 0
+	ResponseType_RESPONSE_TYPE_SHELTER     ResponseType = 1
+	ResponseType_RESPONSE_TYPE_EVACUATE    ResponseType = 2
+	ResponseType_RESPONSE_TYPE_PREPARE     ResponseType = 3
+	ResponseType_RESPONSE_TYPE_EXECUTE     ResponseType = 4
+	ResponseType_RESPONSE_TYPE_AVOID       ResponseType = 5
+	ResponseType_RESPONSE_TYPE_MONITOR     ResponseType = 6
+	ResponseType_RESPONSE_TYPE_ASSESS      ResponseType = 7
+	ResponseType_RESPONSE_TYPE_ALL_CLEAR   ResponseType = 8
+	ResponseType_RESPONSE_TYPE_NONE        ResponseType = 9
+)
+
+// Enum value maps for ResponseType.
+var (
+	ResponseType_name = map[int32]string{
+		0: "RESPONSE_TYPE_UNSPECIFIED",
+		1: "RESPONSE_TYPE_SHELTER",
+		2: "RESPONSE_TYPE_EVACUATE",
+		3: "RESPONSE_TYPE_PREPARE",
+		4: "RESPONSE_TYPE_EXECUTE",
+		5: "RESPONSE_TYPE_AVOID",
+		6: "RESPONSE_TYPE_MONITOR",
+		7: "RESPONSE_TYPE_ASSESS",
+		8: "RESPONSE_TYPE_ALL_CLEAR",
+		9: "RESPONSE_TYPE_NONE",
+	}
+	ResponseType_value = map[string]int32{
+		"RESPONSE_TYPE_UNSPECIFIED": 0,
+		"RESPONSE_TYPE_SHELTER":     1,
+		"RESPONSE_TYPE_EVACUATE":    2,
+		"RESPONSE_TYPE_PREPARE":     3,
+		"RESPONSE_TYPE_EXECUTE":     4,
+		"RESPONSE_TYPE_AVOID":       5,
+		"RESPONSE_TYPE_MONITOR":     6,
+		"RESPONSE_TYPE_ASSESS":      7,
+		"RESPONSE_TYPE_ALL_CLEAR":   8,
+		"RESPONSE_TYPE_NONE":        9,
+	}
+)
+
+func (x ResponseType) Enum() *ResponseType {
+	p := new(ResponseType)
+	*p = x
+	return p
+}
+
+func (x ResponseType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ResponseType) Descriptor() protoreflect.EnumDescriptor {
+	return file_cap_proto_enumTypes[4].Descriptor()
+}
+
+func (ResponseType) Type() protoreflect.EnumType {
+	return &file_cap_proto_enumTypes[4]
+}
+
+func (x ResponseType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ResponseType.Descriptor instead.
+func (ResponseType) EnumDescriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{4}
+}
+
+// Urgency is the code denoting the urgency of the subject event.
+type Urgency int32
+
+const (
+	Urgency_URGENCY_UNSPECIFIED Urgency = 0
+	Urgency_URGENCY_IMMEDIATE   Urgency = 1
+	Urgency_URGENCY_EXPECTED    Urgency = 2
+	Urgency_URGENCY_FUTURE      Urgency = 3
+	Urgency_URGENCY_PAST        Urgency = 4
+	Urgency_URGENCY_UNKNOWN     Urgency = 5
+)
+
+// Enum value maps for Urgency.
+var (
+	Urgency_name = map[int32]string{
+		0: "URGENCY_UNSPECIFIED",
+		1: "URGENCY_IMMEDIATE",
+		2: "URGENCY_EXPECTED",
+		3: "URGENCY_FUTURE",
+		4: "URGENCY_PAST",
+		5: "URGENCY_UNKNOWN",
+	}
+	Urgency_value = map[string]int32{
+		"URGENCY_UNSPECIFIED": 0,
+		"URGENCY_IMMEDIATE":   1,
+		"URGENCY_EXPECTED":    2,
+		"URGENCY_FUTURE":      3,
+		"URGENCY_PAST":        4,
+		"URGENCY_UNKNOWN":     5,
+	}
+)
+
+func (x Urgency) Enum() *Urgency {
+	p := new(Urgency)
+	*p = x
+	return p
+}
+
+func (x Urgency) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Urgency) Descriptor() protoreflect.EnumDescriptor {
+	return file_cap_proto_enumTypes[5].Descriptor()
+}
+
+func (Urgency) Type() protoreflect.EnumType {
+	return &file_cap_proto_enumTypes[5]
+}
+
+func (x Urgency) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Urgency.Descriptor instead.
+func (Urgency) EnumDescriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{5}
+}
+
+// Severity is the code denoting the severity of the subject event.
+type Severity int32
+
+const (
+	Severity_SEVERITY_UNSPECIFIED Severity = 0
+	Severity_SEVERITY_EXTREME     Severity = 1
+	Severity_SEVERITY_SEVERE      Severity = 2
+	Severity_SEVERITY_MODERATE    Severity = 3
+	Severity_SEVERITY_MINOR       Severity = 4
+	Severity_SEVERITY_UNKNOWN     Severity = 5
+)
+
+// Enum value maps for Severity.
+var (
+	Severity_name = map[int32]string{
+		0: "SEVERITY_UNSPECIFIED",
+		1: "SEVERITY_EXTREME",
+		2: "SEVERITY_SEVERE",
+		3: "SEVERITY_MODERATE",
+		4: "SEVERITY_MINOR",
+		5: "SEVERITY_UNKNOWN",
+	}
+	Severity_value = map[string]int32{
+		"SEVERITY_UNSPECIFIED": 0,
+		"SEVERITY_EXTREME":     1,
+		"SEVERITY_SEVERE":      2,
+		"SEVERITY_MODERATE":    3,
+		"SEVERITY_MINOR":       4,
+		"SEVERITY_UNKNOWN":     5,
+	}
+)
+
+func (x Severity) Enum() *Severity {
+	p := new(Severity)
+	*p = x
+	return p
+}
+
+func (x Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_cap_proto_enumTypes[6].Descriptor()
+}
+
+func (Severity) Type() protoreflect.EnumType {
+	return &file_cap_proto_enumTypes[6]
+}
+
+func (x Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Severity.Descriptor instead.
+func (Severity) EnumDescriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{6}
+}
+
+// Certainty is the code denoting the certainty of the subject event.
+type Certainty int32
+
+const (
+	Certainty_CERTAINTY_UNSPECIFIED Certainty = 0
+	Certainty_CERTAINTY_OBSERVED    Certainty = 1
+	Certainty_CERTAINTY_LIKELY      Certainty = 2
+	Certainty_CERTAINTY_POSSIBLE    Certainty = 3
+	Certainty_CERTAINTY_UNLIKELY    Certainty = 4
+	Certainty_CERTAINTY_UNKNOWN     Certainty = 5
+)
+
+// Enum value maps for Certainty.
+var (
+	Certainty_name = map[int32]string{
+		0: "CERTAINTY_UNSPECIFIED",
+		1: "CERTAINTY_OBSERVED",
+		2: "CERTAINTY_LIKELY",
+		3: "CERTAINTY_POSSIBLE",
+		4: "CERTAINTY_UNLIKELY",
+		5: "CERTAINTY_UNKNOWN",
+	}
+	Certainty_value = map[string]int32{
+		"CERTAINTY_UNSPECIFIED": 0,
+		"CERTAINTY_OBSERVED":    1,
+		"CERTAINTY_LIKELY":      2,
+		"CERTAINTY_POSSIBLE":    3,
+		"CERTAINTY_UNLIKELY":    4,
+		"CERTAINTY_UNKNOWN":     5,
+	}
+)
+
+func (x Certainty) Enum() *Certainty {
+	p := new(Certainty)
+	*p = x
+	return p
+}
+
+func (x Certainty) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Certainty) Descriptor() protoreflect.EnumDescriptor {
+	return file_cap_proto_enumTypes[7].Descriptor()
+}
+
+func (Certainty) Type() protoreflect.EnumType {
+	return &file_cap_proto_enumTypes[7]
+}
+
+func (x Certainty) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Certainty.Descriptor instead.
+func (Certainty) EnumDescriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{7}
+}
+
+// Alert provides basic information about the current message: its purpose,
+// its source and its status.
+type Alert struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Identifier string `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	Sender     string `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	// CAP dateTime, such as "2003-06-17T14:57:00-07:00".
+	Sent        string   `protobuf:"bytes,3,opt,name=sent,proto3" json:"sent,omitempty"`
+	Status      Status   `protobuf:"varint,4,opt,name=status,proto3,enum=cap.Status" json:"status,omitempty"`
+	MsgType     MsgType  `protobuf:"varint,5,opt,name=msg_type,json=msgType,proto3,enum=cap.MsgType" json:"msg_type,omitempty"`
+	Source      *string  `protobuf:"bytes,6,opt,name=source,proto3,oneof" json:"source,omitempty"`
+	Scope       Scope    `protobuf:"varint,7,opt,name=scope,proto3,enum=cap.Scope" json:"scope,omitempty"`
+	Restriction *string  `protobuf:"bytes,8,opt,name=restriction,proto3,oneof" json:"restriction,omitempty"`
+	Addresses   []string `protobuf:"bytes,9,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	Code        []string `protobuf:"bytes,10,rep,name=code,proto3" json:"code,omitempty"`
+	Note        *string  `protobuf:"bytes,11,opt,name=note,proto3,oneof" json:"note,omitempty"`
+	// Earlier messages, each as "sender,identifier,sent".
+	References []string     `protobuf:"bytes,12,rep,name=references,proto3" json:"references,omitempty"`
+	Incidents  []string     `protobuf:"bytes,13,rep,name=incidents,proto3" json:"incidents,omitempty"`
+	Info       []*Info      `protobuf:"bytes,14,rep,name=info,proto3" json:"info,omitempty"`
+	Signature  []*Signature `protobuf:"bytes,15,rep,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *Alert) Reset() {
+	*x = Alert{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cap_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Alert) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alert) ProtoMessage() {}
+
+func (x *Alert) ProtoReflect() protoreflect.Message {
+	mi := &file_cap_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alert.ProtoReflect.Descriptor instead.
+func (*Alert) Descriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Alert) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+func (x *Alert) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *Alert) GetSent() string {
+	if x != nil {
+		return x.Sent
+	}
+	return ""
+}
+
+func (x *Alert) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *Alert) GetMsgType() MsgType {
+	if x != nil {
+		return x.MsgType
+	}
+	return MsgType_MSG_TYPE_UNSPECIFIED
+}
+
+func (x *Alert) GetSource() string {
+	if x != nil && x.Source != nil {
+		return *x.Source
+	}
+	return ""
+}
+
+func (x *Alert) GetScope() Scope {
+	if x != nil {
+		return x.Scope
+	}
+	return Scope_SCOPE_UNSPECIFIED
+}
+
+func (x *Alert) GetRestriction() string {
+	if x != nil && x.Restriction != nil {
+		return *x.Restriction
+	}
+	return ""
+}
+
+func (x *Alert) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *Alert) GetCode() []string {
+	if x != nil {
+		return x.Code
+	}
+	return nil
+}
+
+func (x *Alert) GetNote() string {
+	if x != nil && x.Note != nil {
+		return *x.Note
+	}
+	return ""
+}
+
+func (x *Alert) GetReferences() []string {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+func (x *Alert) GetIncidents() []string {
+	if x != nil {
+		return x.Incidents
+	}
+	return nil
+}
+
+func (x *Alert) GetInfo() []*Info {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *Alert) GetSignature() []*Signature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// Info describes an anticipated or actual event.
+type Info struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Language     *string        `protobuf:"bytes,1,opt,name=language,proto3,oneof" json:"language,omitempty"`
+	Category     []Category     `protobuf:"varint,2,rep,packed,name=category,proto3,enum=cap.Category" json:"category,omitempty"`
+	Event        string         `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	ResponseType []ResponseType `protobuf:"varint,4,rep,packed,name=response_type,json=responseType,proto3,enum=cap.ResponseType" json:"response_type,omitempty"`
+	Urgency      Urgency        `protobuf:"varint,5,opt,name=urgency,proto3,enum=cap.Urgency" json:"urgency,omitempty"`
+	Severity     Severity       `protobuf:"varint,6,opt,name=severity,proto3,enum=cap.Severity" json:"severity,omitempty"`
+	Certainty    Certainty      `protobuf:"varint,7,opt,name=certainty,proto3,enum=cap.Certainty" json:"certainty,omitempty"`
+	Audience     *string        `protobuf:"bytes,8,opt,name=audience,proto3,oneof" json:"audience,omitempty"`
+	EventCode    []*KeyValue    `protobuf:"bytes,9,rep,name=event_code,json=eventCode,proto3" json:"event_code,omitempty"`
+	// CAP dateTime values.
+	Effective   *string     `protobuf:"bytes,10,opt,name=effective,proto3,oneof" json:"effective,omitempty"`
+	Onset       *string     `protobuf:"bytes,11,opt,name=onset,proto3,oneof" json:"onset,omitempty"`
+	Expires     *string     `protobuf:"bytes,12,opt,name=expires,proto3,oneof" json:"expires,omitempty"`
+	SenderName  *string     `protobuf:"bytes,13,opt,name=sender_name,json=senderName,proto3,oneof" json:"sender_name,omitempty"`
+	Headline    *string     `protobuf:"bytes,14,opt,name=headline,proto3,oneof" json:"headline,omitempty"`
+	Description *string     `protobuf:"bytes,15,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Instruction *string     `protobuf:"bytes,16,opt,name=instruction,proto3,oneof" json:"instruction,omitempty"`
+	Web         *string     `protobuf:"bytes,17,opt,name=web,proto3,oneof" json:"web,omitempty"`
+	Contact     *string     `protobuf:"bytes,18,opt,name=contact,proto3,oneof" json:"contact,omitempty"`
+	Parameter   []*KeyValue `protobuf:"bytes,19,rep,name=parameter,proto3" json:"parameter,omitempty"`
+	Resource    []*Resource `protobuf:"bytes,20,rep,name=resource,proto3" json:"resource,omitempty"`
+	Area        []*Area     `protobuf:"bytes,21,rep,name=area,proto3" json:"area,omitempty"`
+}
+
+func (x *Info) Reset() {
+	*x = Info{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cap_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Info) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Info) ProtoMessage() {}
+
+func (x *Info) ProtoReflect() protoreflect.Message {
+	mi := &file_cap_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Info.ProtoReflect.Descriptor instead.
+func (*Info) Descriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Info) GetLanguage() string {
+	if x != nil && x.Language != nil {
+		return *x.Language
+	}
+	return ""
+}
+
+func (x *Info) GetCategory() []Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *Info) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *Info) GetResponseType() []ResponseType {
+	if x != nil {
+		return x.ResponseType
+	}
+	return nil
+}
+
+func (x *Info) GetUrgency() Urgency {
+	if x != nil {
+		return x.Urgency
+	}
+	return Urgency_URGENCY_UNSPECIFIED
+}
+
+func (x *Info) GetSeverity() Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Severity_SEVERITY_UNSPECIFIED
+}
+
+func (x *Info) GetCertainty() Certainty {
+	if x != nil {
+		return x.Certainty
+	}
+	return Certainty_CERTAINTY_UNSPECIFIED
+}
+
+func (x *Info) GetAudience() string {
+	if x != nil && x.Audience != nil {
+		return *x.Audience
+	}
+	return ""
+}
+
+func (x *Info) GetEventCode() []*KeyValue {
+	if x != nil {
+		return x.EventCode
+	}
+	return nil
+}
+
+func (x *Info) GetEffective() string {
+	if x != nil && x.Effective != nil {
+		return *x.Effective
+	}
+	return ""
+}
+
+func (x *Info) GetOnset() string {
+	if x != nil && x.Onset != nil {
+		return *x.Onset
+	}
+	return ""
+}
+
+func (x *Info) GetExpires() string {
+	if x != nil && x.Expires != nil {
+		return *x.Expires
+	}
+	return ""
+}
+
+func (x *Info) GetSenderName() string {
+	if x != nil && x.SenderName != nil {
+		return *x.SenderName
+	}
+	return ""
+}
+
+func (x *Info) GetHeadline() string {
+	if x != nil && x.Headline != nil {
+		return *x.Headline
+	}
+	return ""
+}
+
+func (x *Info) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Info) GetInstruction() string {
+	if x != nil && x.Instruction != nil {
+		return *x.Instruction
+	}
+	return ""
+}
+
+func (x *Info) GetWeb() string {
+	if x != nil && x.Web != nil {
+		return *x.Web
+	}
+	return ""
+}
+
+func (x *Info) GetContact() string {
+	if x != nil && x.Contact != nil {
+		return *x.Contact
+	}
+	return ""
+}
+
+func (x *Info) GetParameter() []*KeyValue {
+	if x != nil {
+		return x.Parameter
+	}
+	return nil
+}
+
+func (x *Info) GetResource() []*Resource {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *Info) GetArea() []*Area {
+	if x != nil {
+		return x.Area
+	}
+	return nil
+}
+
+// KeyValue is a system-specific code or parameter.
+type KeyValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValueName string `protobuf:"bytes,1,opt,name=value_name,json=valueName,proto3" json:"value_name,omitempty"`
+	Value     string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *KeyValue) Reset() {
+	*x = KeyValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cap_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyValue) ProtoMessage() {}
+
+func (x *KeyValue) ProtoReflect() protoreflect.Message {
+	mi := &file_cap_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyValue.ProtoReflect.Descriptor instead.
+func (*KeyValue) Descriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *KeyValue) GetValueName() string {
+	if x != nil {
+		return x.ValueName
+	}
+	return ""
+}
+
+func (x *KeyValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Resource is a digital asset, such as an image or audio file.
+type Resource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResourceDesc string  `protobuf:"bytes,1,opt,name=resource_desc,json=resourceDesc,proto3" json:"resource_desc,omitempty"`
+	MimeType     string  `protobuf:"bytes,2,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Size         *int64  `protobuf:"varint,3,opt,name=size,proto3,oneof" json:"size,omitempty"`
+	Uri          *string `protobuf:"bytes,4,opt,name=uri,proto3,oneof" json:"uri,omitempty"`
+	// Base-64 encoded data content of the resource file.
+	DerefUri *string `protobuf:"bytes,5,opt,name=deref_uri,json=derefUri,proto3,oneof" json:"deref_uri,omitempty"`
+	Digest   *string `protobuf:"bytes,6,opt,name=digest,proto3,oneof" json:"digest,omitempty"`
+}
+
+func (x *Resource) Reset() {
+	*x = Resource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cap_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Resource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Resource) ProtoMessage() {}
+
+func (x *Resource) ProtoReflect() protoreflect.Message {
+	mi := &file_cap_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Resource.ProtoReflect.Descriptor instead.
+func (*Resource) Descriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Resource) GetResourceDesc() string {
+	if x != nil {
+		return x.ResourceDesc
+	}
+	return ""
+}
+
+func (x *Resource) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *Resource) GetSize() int64 {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return 0
+}
+
+func (x *Resource) GetUri() string {
+	if x != nil && x.Uri != nil {
+		return *x.Uri
+	}
+	return ""
+}
+
+func (x *Resource) GetDerefUri() string {
+	if x != nil && x.DerefUri != nil {
+		return *x.DerefUri
+	}
+	return ""
+}
+
+func (x *Resource) GetDigest() string {
+	if x != nil && x.Digest != nil {
+		return *x.Digest
+	}
+	return ""
+}
+
+// Area is a geographic area to which the Info applies.
+type Area struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AreaDesc string      `protobuf:"bytes,1,opt,name=area_desc,json=areaDesc,proto3" json:"area_desc,omitempty"`
+	Polygon  *Polygon    `protobuf:"bytes,2,opt,name=polygon,proto3" json:"polygon,omitempty"`
+	Circle   []*Circle   `protobuf:"bytes,3,rep,name=circle,proto3" json:"circle,omitempty"`
+	Geocode  []*KeyValue `protobuf:"bytes,4,rep,name=geocode,proto3" json:"geocode,omitempty"`
+	// Altitude and ceiling in feet above mean sea level.
+	Altitude *float32 `protobuf:"fixed32,5,opt,name=altitude,proto3,oneof" json:"altitude,omitempty"`
+	Ceiling  *float32 `protobuf:"fixed32,6,opt,name=ceiling,proto3,oneof" json:"ceiling,omitempty"`
+}
+
+func (x *Area) Reset() {
+	*x = Area{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cap_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Area) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Area) ProtoMessage() {}
+
+func (x *Area) ProtoReflect() protoreflect.Message {
+	mi := &file_cap_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Area.ProtoReflect.Descriptor instead.
+func (*Area) Descriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Area) GetAreaDesc() string {
+	if x != nil {
+		return x.AreaDesc
+	}
+	return ""
+}
+
+func (x *Area) GetPolygon() *Polygon {
+	if x != nil {
+		return x.Polygon
+	}
+	return nil
+}
+
+func (x *Area) GetCircle() []*Circle {
+	if x != nil {
+		return x.Circle
+	}
+	return nil
+}
+
+func (x *Area) GetGeocode() []*KeyValue {
+	if x != nil {
+		return x.Geocode
+	}
+	return nil
+}
+
+func (x *Area) GetAltitude() float32 {
+	if x != nil && x.Altitude != nil {
+		return *x.Altitude
+	}
+	return 0
+}
+
+func (x *Area) GetCeiling() float32 {
+	if x != nil && x.Ceiling != nil {
+		return *x.Ceiling
+	}
+	return 0
+}
+
+// Point is a WGS 84 latitude / longitude.
+type Point struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cap_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_cap_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Point) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Point) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+// Polygon is a closed ring of points, the first and last being equal.
+type Polygon struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Points []*Point `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+}
+
+func (x *Polygon) Reset() {
+	*x = Polygon{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cap_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Polygon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Polygon) ProtoMessage() {}
+
+func (x *Polygon) ProtoReflect() protoreflect.Message {
+	mi := &file_cap_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Polygon.ProtoReflect.Descriptor instead.
+func (*Polygon) Descriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Polygon) GetPoints() []*Point {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+// Circle is a center point and a radius in kilometers.
+type Circle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Center *Point  `protobuf:"bytes,1,opt,name=center,proto3" json:"center,omitempty"`
+	Radius float64 `protobuf:"fixed64,2,opt,name=radius,proto3" json:"radius,omitempty"`
+}
+
+func (x *Circle) Reset() {
+	*x = Circle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cap_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Circle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Circle) ProtoMessage() {}
+
+func (x *Circle) ProtoReflect() protoreflect.Message {
+	mi := &file_cap_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Circle.ProtoReflect.Descriptor instead.
+func (*Circle) Descriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Circle) GetCenter() *Point {
+	if x != nil {
+		return x.Center
+	}
+	return nil
+}
+
+func (x *Circle) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+// Signature is a standard XML digital signature. Algorithms are given by their
+// URIs.
+type Signature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                  *string              `protobuf:"bytes,1,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	SignedInfo          *SignedInfo          `protobuf:"bytes,2,opt,name=signed_info,json=signedInfo,proto3" json:"signed_info,omitempty"`
+	SignatureValue      string               `protobuf:"bytes,3,opt,name=signature_value,json=signatureValue,proto3" json:"signature_value,omitempty"`
+	X509Certificate     *string              `protobuf:"bytes,4,opt,name=x509_certificate,json=x509Certificate,proto3,oneof" json:"x509_certificate,omitempty"`
+	SignatureProperties []*SignatureProperty `protobuf:"bytes,5,rep,name=signature_properties,json=signatureProperties,proto3" json:"signature_properties,omitempty"`
+}
+
+func (x *Signature) Reset() {
+	*x = Signature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cap_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Signature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Signature) ProtoMessage() {}
+
+func (x *Signature) ProtoReflect() protoreflect.Message {
+	mi := &file_cap_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Signature.ProtoReflect.Descriptor instead.
+func (*Signature) Descriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Signature) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *Signature) GetSignedInfo() *SignedInfo {
+	if x != nil {
+		return x.SignedInfo
+	}
+	return nil
+}
+
+func (x *Signature) GetSignatureValue() string {
+	if x != nil {
+		return x.SignatureValue
+	}
+	return ""
+}
+
+func (x *Signature) GetX509Certificate() string {
+	if x != nil && x.X509Certificate != nil {
+		return *x.X509Certificate
+	}
+	return ""
+}
+
+func (x *Signature) GetSignatureProperties() []*SignatureProperty {
+	if x != nil {
+		return x.SignatureProperties
+	}
+	return nil
+}
+
+// SignedInfo references the signed data and the algorithms used.
+type SignedInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CanonicalizationMethod string     `protobuf:"bytes,1,opt,name=canonicalization_method,json=canonicalizationMethod,proto3" json:"canonicalization_method,omitempty"`
+	SignatureMethod        string     `protobuf:"bytes,2,opt,name=signature_method,json=signatureMethod,proto3" json:"signature_method,omitempty"`
+	Reference              *Reference `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
+}
+
+func (x *SignedInfo) Reset() {
+	*x = SignedInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cap_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignedInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedInfo) ProtoMessage() {}
+
+func (x *SignedInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_cap_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedInfo.ProtoReflect.Descriptor instead.
+func (*SignedInfo) Descriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SignedInfo) GetCanonicalizationMethod() string {
+	if x != nil {
+		return x.CanonicalizationMethod
+	}
+	return ""
+}
+
+func (x *SignedInfo) GetSignatureMethod() string {
+	if x != nil {
+		return x.SignatureMethod
+	}
+	return ""
+}
+
+func (x *SignedInfo) GetReference() *Reference {
+	if x != nil {
+		return x.Reference
+	}
+	return nil
+}
+
+// Reference is the resource being signed, and the transform applied to it.
+type Reference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uri          string `protobuf:"bytes,1,opt,name=uri,proto3" json:"uri,omitempty"`
+	Transform    string `protobuf:"bytes,2,opt,name=transform,proto3" json:"transform,omitempty"`
+	DigestMethod string `protobuf:"bytes,3,opt,name=digest_method,json=digestMethod,proto3" json:"digest_method,omitempty"`
+	DigestValue  string `protobuf:"bytes,4,opt,name=digest_value,json=digestValue,proto3" json:"digest_value,omitempty"`
+}
+
+func (x *Reference) Reset() {
+	*x = Reference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cap_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Reference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reference) ProtoMessage() {}
+
+func (x *Reference) ProtoReflect() protoreflect.Message {
+	mi := &file_cap_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reference.ProtoReflect.Descriptor instead.
+func (*Reference) Descriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Reference) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *Reference) GetTransform() string {
+	if x != nil {
+		return x.Transform
+	}
+	return ""
+}
+
+func (x *Reference) GetDigestMethod() string {
+	if x != nil {
+		return x.DigestMethod
+	}
+	return ""
+}
+
+func (x *Reference) GetDigestValue() string {
+	if x != nil {
+		return x.DigestValue
+	}
+	return ""
+}
+
+// SignatureProperty is the signed data of an enveloping signature.
+type SignatureProperty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Xc     string `protobuf:"bytes,3,opt,name=xc,proto3" json:"xc,omitempty"`
+}
+
+func (x *SignatureProperty) Reset() {
+	*x = SignatureProperty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cap_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignatureProperty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignatureProperty) ProtoMessage() {}
+
+func (x *SignatureProperty) ProtoReflect() protoreflect.Message {
+	mi := &file_cap_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignatureProperty.ProtoReflect.Descriptor instead.
+func (*SignatureProperty) Descriptor() ([]byte, []int) {
+	return file_cap_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SignatureProperty) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SignatureProperty) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *SignatureProperty) GetXc() string {
+	if x != nil {
+		return x.Xc
+	}
+	return ""
+}
+
+var File_cap_proto protoreflect.FileDescriptor
+
+var file_cap_proto_rawDesc = []byte{
+	0x0a, 0x09, 0x63, 0x61, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x63, 0x61, 0x70,
+	0x22, 0x81, 0x04, 0x0a, 0x05, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x27, 0x0a, 0x08, 0x6d,
+	0x73, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e,
+	0x63, 0x61, 0x70, 0x2e, 0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x6d, 0x73, 0x67,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x88, 0x01,
+	0x01, 0x12, 0x20, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0a, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63,
+	0x6f, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x74,
+	0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x17, 0x0a, 0x04,
+	0x6e, 0x6f, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x04, 0x6e, 0x6f,
+	0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x63, 0x69, 0x64, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x63, 0x69, 0x64, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x0e, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x09, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e,
+	0x66, 0x6f, 0x12, 0x2c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f,
+	0x72, 0x65, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x5f,
+	0x6e, 0x6f, 0x74, 0x65, 0x22, 0x9e, 0x07, 0x0a, 0x04, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1f, 0x0a,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12, 0x29,
+	0x0a, 0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e,
+	0x32, 0x0d, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x52,
+	0x08, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x36, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a, 0x07, 0x75, 0x72, 0x67, 0x65, 0x6e,
+	0x63, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x55,
+	0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x07, 0x75, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x12,
+	0x29, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x0d, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79,
+	0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x2c, 0x0a, 0x09, 0x63, 0x65,
+	0x72, 0x74, 0x61, 0x69, 0x6e, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e,
+	0x63, 0x61, 0x70, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x61, 0x69, 0x6e, 0x74, 0x79, 0x52, 0x09, 0x63,
+	0x65, 0x72, 0x74, 0x61, 0x69, 0x6e, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x08, 0x61, 0x75, 0x64, 0x69,
+	0x65, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x08, 0x61, 0x75,
+	0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x0a, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x63, 0x61, 0x70, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x09, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x09, 0x65, 0x66, 0x66, 0x65, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x09, 0x65, 0x66,
+	0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x6f, 0x6e,
+	0x73, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x05, 0x6f, 0x6e, 0x73,
+	0x65, 0x74, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x48, 0x04, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0b, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x48, 0x05, 0x52, 0x0a, 0x73, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x68, 0x65,
+	0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x48, 0x06, 0x52, 0x08,
+	0x68, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x07, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88,
+	0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x48, 0x08, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x03, 0x77, 0x65, 0x62,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x48, 0x09, 0x52, 0x03, 0x77, 0x65, 0x62, 0x88, 0x01, 0x01,
+	0x12, 0x1d, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x0a, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x88, 0x01, 0x01, 0x12,
+	0x2b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x18, 0x13, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x09, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x08,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x63, 0x61, 0x70, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x04, 0x61, 0x72, 0x65, 0x61, 0x18,
+	0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x41, 0x72, 0x65, 0x61,
+	0x52, 0x04, 0x61, 0x72, 0x65, 0x61, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x67, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65,
+	0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x42, 0x08,
+	0x0a, 0x06, 0x5f, 0x6f, 0x6e, 0x73, 0x65, 0x74, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e,
+	0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x77, 0x65, 0x62, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x63, 0x74, 0x22, 0x3f, 0x0a, 0x08, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xe5, 0x01, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x64, 0x65, 0x73, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x73, 0x63, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6d, 0x65,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x69, 0x6d,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x12, 0x15,
+	0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x03, 0x75,
+	0x72, 0x69, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x64, 0x65, 0x72, 0x65, 0x66, 0x5f, 0x75,
+	0x72, 0x69, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x08, 0x64, 0x65, 0x72, 0x65,
+	0x66, 0x55, 0x72, 0x69, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x03, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x06, 0x0a,
+	0x04, 0x5f, 0x75, 0x72, 0x69, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x64, 0x65, 0x72, 0x65, 0x66, 0x5f,
+	0x75, 0x72, 0x69, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0xf2,
+	0x01, 0x0a, 0x04, 0x41, 0x72, 0x65, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x72, 0x65, 0x61, 0x5f,
+	0x64, 0x65, 0x73, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x72, 0x65, 0x61,
+	0x44, 0x65, 0x73, 0x63, 0x12, 0x26, 0x0a, 0x07, 0x70, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x50, 0x6f, 0x6c, 0x79,
+	0x67, 0x6f, 0x6e, 0x52, 0x07, 0x70, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x06,
+	0x63, 0x69, 0x72, 0x63, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x63,
+	0x61, 0x70, 0x2e, 0x43, 0x69, 0x72, 0x63, 0x6c, 0x65, 0x52, 0x06, 0x63, 0x69, 0x72, 0x63, 0x6c,
+	0x65, 0x12, 0x27, 0x0a, 0x07, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x07, 0x67, 0x65, 0x6f, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x08, 0x61, 0x6c,
+	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x08,
+	0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x63,
+	0x65, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x48, 0x01, 0x52, 0x07,
+	0x63, 0x65, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x61,
+	0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x63, 0x65, 0x69, 0x6c,
+	0x69, 0x6e, 0x67, 0x22, 0x41, 0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e,
+	0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22, 0x2d, 0x0a, 0x07, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f,
+	0x6e, 0x12, 0x22, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0a, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x44, 0x0a, 0x06, 0x43, 0x69, 0x72, 0x63, 0x6c, 0x65, 0x12,
+	0x22, 0x0a, 0x06, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0a, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x63, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x22, 0x92, 0x02, 0x0a, 0x09,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x13, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x02, 0x69, 0x64, 0x88, 0x01, 0x01, 0x12, 0x30,
+	0x0a, 0x0b, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x27, 0x0a, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2e, 0x0a, 0x10, 0x78, 0x35, 0x30,
+	0x39, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0f, 0x78, 0x35, 0x30, 0x39, 0x43, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x49, 0x0a, 0x14, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x52,
+	0x13, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72,
+	0x74, 0x69, 0x65, 0x73, 0x42, 0x05, 0x0a, 0x03, 0x5f, 0x69, 0x64, 0x42, 0x13, 0x0a, 0x11, 0x5f,
+	0x78, 0x35, 0x30, 0x39, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x22, 0x9e, 0x01, 0x0a, 0x0a, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x37, 0x0a, 0x17, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x16, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x12, 0x2c, 0x0a, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63, 0x61, 0x70, 0x2e, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x22, 0x83, 0x01, 0x0a, 0x09, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
+	0x69, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x12,
+	0x23, 0x0a, 0x0d, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x4d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x4b, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x78, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x78, 0x63, 0x2a, 0x7e, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16,
+	0x0a, 0x12, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53,
+	0x5f, 0x41, 0x43, 0x54, 0x55, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x5f, 0x45, 0x58, 0x45, 0x52, 0x43, 0x49, 0x53, 0x45, 0x10, 0x02, 0x12, 0x11,
+	0x0a, 0x0d, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10,
+	0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x54, 0x45, 0x53, 0x54,
+	0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x44, 0x52, 0x41,
+	0x46, 0x54, 0x10, 0x05, 0x2a, 0x87, 0x01, 0x0a, 0x07, 0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x18, 0x0a, 0x14, 0x4d, 0x53, 0x47, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x4d, 0x53,
+	0x47, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x4c, 0x45, 0x52, 0x54, 0x10, 0x01, 0x12, 0x13,
+	0x0a, 0x0f, 0x4d, 0x53, 0x47, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54,
+	0x45, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x4d, 0x53, 0x47, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x4d, 0x53, 0x47, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x43, 0x4b, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x4d, 0x53,
+	0x47, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x05, 0x2a, 0x59,
+	0x0a, 0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x43, 0x4f, 0x50, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10,
+	0x0a, 0x0c, 0x53, 0x43, 0x4f, 0x50, 0x45, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x43, 0x10, 0x01,
+	0x12, 0x14, 0x0a, 0x10, 0x53, 0x43, 0x4f, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x53, 0x54, 0x52, 0x49,
+	0x43, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x43, 0x4f, 0x50, 0x45, 0x5f,
+	0x50, 0x52, 0x49, 0x56, 0x41, 0x54, 0x45, 0x10, 0x03, 0x2a, 0x97, 0x02, 0x0a, 0x08, 0x43, 0x61,
+	0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f,
+	0x52, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x10, 0x0a, 0x0c, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x47, 0x45, 0x4f,
+	0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x4d,
+	0x45, 0x54, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59,
+	0x5f, 0x53, 0x41, 0x46, 0x45, 0x54, 0x59, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x41, 0x54,
+	0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x10, 0x04,
+	0x12, 0x13, 0x0a, 0x0f, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x52, 0x45, 0x53,
+	0x43, 0x55, 0x45, 0x10, 0x05, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52,
+	0x59, 0x5f, 0x46, 0x49, 0x52, 0x45, 0x10, 0x06, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x41, 0x54, 0x45,
+	0x47, 0x4f, 0x52, 0x59, 0x5f, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x10, 0x07, 0x12, 0x10, 0x0a,
+	0x0c, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x45, 0x4e, 0x56, 0x10, 0x08, 0x12,
+	0x16, 0x0a, 0x12, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x54, 0x52, 0x41, 0x4e,
+	0x53, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x09, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x41, 0x54, 0x45, 0x47,
+	0x4f, 0x52, 0x59, 0x5f, 0x49, 0x4e, 0x46, 0x52, 0x41, 0x10, 0x0a, 0x12, 0x12, 0x0a, 0x0e, 0x43,
+	0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x43, 0x42, 0x52, 0x4e, 0x45, 0x10, 0x0b, 0x12,
+	0x12, 0x0a, 0x0e, 0x43, 0x41, 0x54, 0x45, 0x47, 0x4f, 0x52, 0x59, 0x5f, 0x4f, 0x54, 0x48, 0x45,
+	0x52, 0x10, 0x0c, 0x2a, 0x9d, 0x02, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x48, 0x45, 0x4c, 0x54, 0x45, 0x52, 0x10, 0x01, 0x12, 0x1a,
+	0x0a, 0x16, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x45, 0x56, 0x41, 0x43, 0x55, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x52, 0x45,
+	0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x52, 0x45, 0x50,
+	0x41, 0x52, 0x45, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53,
+	0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x45, 0x10, 0x04,
+	0x12, 0x17, 0x0a, 0x13, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x41, 0x56, 0x4f, 0x49, 0x44, 0x10, 0x05, 0x12, 0x19, 0x0a, 0x15, 0x52, 0x45, 0x53,
+	0x50, 0x4f, 0x4e, 0x53, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x4f, 0x4e, 0x49, 0x54,
+	0x4f, 0x52, 0x10, 0x06, 0x12, 0x18, 0x0a, 0x14, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x10, 0x07, 0x12, 0x1b,
+	0x0a, 0x17, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x41, 0x4c, 0x4c, 0x5f, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x10, 0x08, 0x12, 0x16, 0x0a, 0x12, 0x52,
+	0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x4f, 0x4e,
+	0x45, 0x10, 0x09, 0x2a, 0x8a, 0x01, 0x0a, 0x07, 0x55, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x12,
+	0x17, 0x0a, 0x13, 0x55, 0x52, 0x47, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x55, 0x52, 0x47, 0x45,
+	0x4e, 0x43, 0x59, 0x5f, 0x49, 0x4d, 0x4d, 0x45, 0x44, 0x49, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12,
+	0x14, 0x0a, 0x10, 0x55, 0x52, 0x47, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x45, 0x58, 0x50, 0x45, 0x43,
+	0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x55, 0x52, 0x47, 0x45, 0x4e, 0x43, 0x59,
+	0x5f, 0x46, 0x55, 0x54, 0x55, 0x52, 0x45, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x52, 0x47,
+	0x45, 0x4e, 0x43, 0x59, 0x5f, 0x50, 0x41, 0x53, 0x54, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x55,
+	0x52, 0x47, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x05,
+	0x2a, 0x90, 0x01, 0x0a, 0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a,
+	0x14, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x45, 0x56, 0x45, 0x52,
+	0x49, 0x54, 0x59, 0x5f, 0x45, 0x58, 0x54, 0x52, 0x45, 0x4d, 0x45, 0x10, 0x01, 0x12, 0x13, 0x0a,
+	0x0f, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x53, 0x45, 0x56, 0x45, 0x52, 0x45,
+	0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4d,
+	0x4f, 0x44, 0x45, 0x52, 0x41, 0x54, 0x45, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45, 0x56,
+	0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4d, 0x49, 0x4e, 0x4f, 0x52, 0x10, 0x04, 0x12, 0x14, 0x0a,
+	0x10, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
+	0x4e, 0x10, 0x05, 0x2a, 0x9b, 0x01, 0x0a, 0x09, 0x43, 0x65, 0x72, 0x74, 0x61, 0x69, 0x6e, 0x74,
+	0x79, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x45, 0x52, 0x54, 0x41, 0x49, 0x4e, 0x54, 0x59, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12,
+	0x43, 0x45, 0x52, 0x54, 0x41, 0x49, 0x4e, 0x54, 0x59, 0x5f, 0x4f, 0x42, 0x53, 0x45, 0x52, 0x56,
+	0x45, 0x44, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x45, 0x52, 0x54, 0x41, 0x49, 0x4e, 0x54,
+	0x59, 0x5f, 0x4c, 0x49, 0x4b, 0x45, 0x4c, 0x59, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x43, 0x45,
+	0x52, 0x54, 0x41, 0x49, 0x4e, 0x54, 0x59, 0x5f, 0x50, 0x4f, 0x53, 0x53, 0x49, 0x42, 0x4c, 0x45,
+	0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x43, 0x45, 0x52, 0x54, 0x41, 0x49, 0x4e, 0x54, 0x59, 0x5f,
+	0x55, 0x4e, 0x4c, 0x49, 0x4b, 0x45, 0x4c, 0x59, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x45,
+	0x52, 0x54, 0x41, 0x49, 0x4e, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
+	0x05, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x74, 0x68, 0x65, 0x74, 0x61, 0x6e, 0x6e, 0x65, 0x72, 0x72, 0x79, 0x61, 0x6e, 0x2f, 0x63, 0x61,
+	0x70, 0x2f, 0x63, 0x61, 0x70, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_cap_proto_rawDescOnce sync.Once
+	file_cap_proto_rawDescData = file_cap_proto_rawDesc
+)
+
+func file_cap_proto_rawDescGZIP() []byte {
+	file_cap_proto_rawDescOnce.Do(func() {
+		file_cap_proto_rawDescData = protoimpl.X.CompressGZIP(file_cap_proto_rawDescData)
+	})
+	return file_cap_proto_rawDescData
+}
+
+var file_cap_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_cap_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_cap_proto_goTypes = []interface{}{
+	(Status)(0),               // 0: cap.Status
+	(MsgType)(0),              // 1: cap.MsgType
+	(Scope)(0),                // 2: cap.Scope
+	(Category)(0),             // 3: cap.Category
+	(ResponseType)(0),         // 4: cap.ResponseType
+	(Urgency)(0),              // 5: cap.Urgency
+	(Severity)(0),             // 6: cap.Severity
+	(Certainty)(0),            // 7: cap.Certainty
+	(*Alert)(nil),             // 8: cap.Alert
+	(*Info)(nil),              // 9: cap.Info
+	(*KeyValue)(nil),          // 10: cap.KeyValue
+	(*Resource)(nil),          // 11: cap.Resource
+	(*Area)(nil),              // 12: cap.Area
+	(*Point)(nil),             // 13: cap.Point
+	(*Polygon)(nil),           // 14: cap.Polygon
+	(*Circle)(nil),            // 15: cap.Circle
+	(*Signature)(nil),         // 16: cap.Signature
+	(*SignedInfo)(nil),        // 17: cap.SignedInfo
+	(*Reference)(nil),         // 18: cap.Reference
+	(*SignatureProperty)(nil), // 19: cap.SignatureProperty
+}
+var file_cap_proto_depIdxs = []int32{
+	0,  // 0: cap.Alert.status:type_name -> cap.Status
+	1,  // 1: cap.Alert.msg_type:type_name -> cap.MsgType
+	2,  // 2: cap.Alert.scope:type_name -> cap.Scope
+	9,  // 3: cap.Alert.info:type_name -> cap.Info
+	16, // 4: cap.Alert.signature:type_name -> cap.Signature
+	3,  // 5: cap.Info.category:type_name -> cap.Category
+	4,  // 6: cap.Info.response_type:type_name -> cap.ResponseType
+	5,  // 7: cap.Info.urgency:type_name -> cap.Urgency
+	6,  // 8: cap.Info.severity:type_name -> cap.Severity
+	7,  // 9: cap.Info.certainty:type_name -> cap.Certainty
+	10, // 10: cap.Info.event_code:type_name -> cap.KeyValue
+	10, // 11: cap.Info.parameter:type_name -> cap.KeyValue
+	11, // 12: cap.Info.resource:type_name -> cap.Resource
+	12, // 13: cap.Info.area:type_name -> cap.Area
+	14, // 14: cap.Area.polygon:type_name -> cap.Polygon
+	15, // 15: cap.Area.circle:type_name -> cap.Circle
+	10, // 16: cap.Area.geocode:type_name -> cap.KeyValue
+	13, // 17: cap.Polygon.points:type_name -> cap.Point
+	13, // 18: cap.Circle.center:type_name -> cap.Point
+	17, // 19: cap.Signature.signed_info:type_name -> cap.SignedInfo
+	19, // 20: cap.Signature.signature_properties:type_name -> cap.SignatureProperty
+	18, // 21: cap.SignedInfo.reference:type_name -> cap.Reference
+	22, // [22:22] is the sub-list for method output_type
+	22, // [22:22] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_cap_proto_init() }
+func file_cap_proto_init() {
+	if File_cap_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_cap_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Alert); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cap_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Info); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cap_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cap_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Resource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cap_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Area); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cap_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Point); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cap_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Polygon); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cap_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Circle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cap_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Signature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cap_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignedInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cap_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Reference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cap_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignatureProperty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_cap_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	file_cap_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	file_cap_proto_msgTypes[3].OneofWrappers = []interface{}{}
+	file_cap_proto_msgTypes[4].OneofWrappers = []interface{}{}
+	file_cap_proto_msgTypes[8].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_cap_proto_rawDesc,
+			NumEnums:      8,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_cap_proto_goTypes,
+		DependencyIndexes: file_cap_proto_depIdxs,
+		EnumInfos:         file_cap_proto_enumTypes,
+		MessageInfos:      file_cap_proto_msgTypes,
+	}.Build()
+	File_cap_proto = out.File
+	file_cap_proto_rawDesc = nil
+	file_cap_proto_goTypes = nil
+	file_cap_proto_depIdxs = nil
+}
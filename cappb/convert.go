@@ -0,0 +1,444 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package cappb is the Protocol Buffers form of CAP alerts, for services that
+exchange alerts over gRPC.
+
+The messages are generated from cap.proto, which covers the full CAP 1.2 model
+including signatures and geometry. ToProto and FromProto convert between the
+messages and *cap.Alert. Optional elements are present in a message only if
+they are set in the Alert, and dateTime values keep their CAP text.
+
+	msg, err := cappb.ToProto(alert)
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(msg)
+*/
+package cappb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative cap.proto
+
+import (
+	"encoding/xml"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/thetannerryan/cap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// capNamespace is the namespace of CAP 1.2 elements.
+const capNamespace = "urn:oasis:names:tc:emergency:cap:1.2"
+
+// enumNumber returns the number of the enum value for the CAP code text, such
+// as RESPONSE_TYPE_ALL_CLEAR for "AllClear".
+func enumNumber(desc protoreflect.EnumDescriptor, prefix, text string) (protoreflect.EnumNumber, error) {
+	name := prefix
+	for i, r := range text {
+		if i > 0 && r >= 'A' && r <= 'Z' && text[i-1] >= 'a' && text[i-1] <= 'z' {
+			name += "_"
+		}
+		name += strings.ToUpper(string(r))
+	}
+	val := desc.Values().ByName(protoreflect.Name(name))
+	if val == nil || val.Number() == 0 {
+		return 0, errors.New("Error: illegal value " + text + " for " + string(desc.Name()) + " code")
+	}
+	return val.Number(), nil
+}
+
+// codeText returns the key of the CAP code mapping for the enum value, such as
+// "AllClear" for RESPONSE_TYPE_ALL_CLEAR.
+func codeText(desc protoreflect.EnumDescriptor, prefix string, num protoreflect.EnumNumber, mapping interface{}) (string, error) {
+	val := desc.Values().ByNumber(num)
+	if val != nil && num != 0 {
+		name := strings.Replace(strings.TrimPrefix(string(val.Name()), prefix), "_", "", -1)
+		for _, key := range reflect.ValueOf(mapping).MapKeys() {
+			if strings.EqualFold(key.String(), name) {
+				return key.String(), nil
+			}
+		}
+	}
+	return "", errors.New("Error: illegal value " + strconv.Itoa(int(num)) + " for " + string(desc.Name()) + " code")
+}
+
+// optional returns a pointer to the text, or nil if it is empty.
+func optional(val string) *string {
+	if val == "" {
+		return nil
+	}
+	return &val
+}
+
+// timeText returns the text of the DateTime, or an empty string if it is unset.
+func timeText(t cap.DateTime) string {
+	if t.Time().IsZero() {
+		return ""
+	}
+	return t.String()
+}
+
+// optionalTime returns a pointer to the text of the DateTime, or nil if it is
+// unset.
+func optionalTime(t cap.DateTime) *string {
+	return optional(timeText(t))
+}
+
+// parseTime returns the DateTime of the text. Empty text is an unset DateTime.
+func parseTime(val string) (cap.DateTime, error) {
+	if val == "" {
+		return cap.DateTime{}, nil
+	}
+	return cap.ParseDateTime(val)
+}
+
+// ToProto returns the message of the Alert. If the Alert has a code kept by
+// lenient parsing, or a polygon or circle that cannot be parsed, an error is
+// returned.
+func ToProto(alert *cap.Alert) (*Alert, error) {
+	msg := &Alert{
+		Identifier:  alert.Identifier,
+		Sender:      alert.Sender,
+		Sent:        timeText(alert.Sent),
+		Source:      optional(alert.Source),
+		Restriction: optional(alert.Restriction),
+		Addresses:   alert.Addresses.Values(),
+		Code:        alert.Code,
+		Note:        optional(alert.Note),
+		References:  alert.References.Values(),
+		Incidents:   alert.Incidents.Values(),
+	}
+	num, err := enumNumber(msg.Status.Descriptor(), "STATUS_", alert.CodeText(alert.Status))
+	if err != nil {
+		return nil, err
+	}
+	msg.Status = Status(num)
+	if num, err = enumNumber(msg.MsgType.Descriptor(), "MSG_TYPE_", alert.CodeText(alert.MsgType)); err != nil {
+		return nil, err
+	}
+	msg.MsgType = MsgType(num)
+	if num, err = enumNumber(msg.Scope.Descriptor(), "SCOPE_", alert.CodeText(alert.Scope)); err != nil {
+		return nil, err
+	}
+	msg.Scope = Scope(num)
+	for i := range alert.Info {
+		info, err := toInfo(&alert.Info[i])
+		if err != nil {
+			return nil, err
+		}
+		msg.Info = append(msg.Info, info)
+	}
+	for _, sig := range alert.Signature {
+		out := &Signature{
+			Id: optional(sig.ID),
+			SignedInfo: &SignedInfo{
+				CanonicalizationMethod: sig.SignedInfo.CanonicalizationMethod.Algorithm,
+				SignatureMethod:        sig.SignedInfo.SignatureMethod.Algorithm,
+				Reference: &Reference{
+					Uri:          sig.SignedInfo.Reference.URI,
+					Transform:    sig.SignedInfo.Reference.Transform.Algorithm,
+					DigestMethod: sig.SignedInfo.Reference.DigestMethod.Algorithm,
+					DigestValue:  sig.SignedInfo.Reference.DigestValue,
+				},
+			},
+			SignatureValue:  sig.SignatureValue,
+			X509Certificate: optional(sig.X509Certificate),
+		}
+		for _, prop := range sig.SignatureProperties {
+			out.SignatureProperties = append(out.SignatureProperties, &SignatureProperty{
+				Id:     prop.ID,
+				Target: prop.Target,
+				Xc:     prop.XCValue.XC,
+			})
+		}
+		msg.Signature = append(msg.Signature, out)
+	}
+	return msg, nil
+}
+
+// toKeyValues returns the messages of the key-value pairs.
+func toKeyValues(pairs []cap.KeyValue) []*KeyValue {
+	var msgs []*KeyValue
+	for _, pair := range pairs {
+		msgs = append(msgs, &KeyValue{ValueName: pair.ValueName, Value: pair.Value})
+	}
+	return msgs
+}
+
+// toPoint returns the message of the Point.
+func toPoint(point cap.Point) *Point {
+	return &Point{Latitude: point.Latitude, Longitude: point.Longitude}
+}
+
+// toInfo returns the message of the Info.
+func toInfo(info *cap.Info) (*Info, error) {
+	msg := &Info{
+		Language:    optional(info.Language),
+		Event:       info.Event,
+		Audience:    optional(info.Audience),
+		EventCode:   toKeyValues(info.EventCode),
+		Effective:   optionalTime(info.Effective),
+		Onset:       optionalTime(info.Onset),
+		Expires:     optionalTime(info.Expires),
+		SenderName:  optional(info.SenderName),
+		Headline:    optional(info.Headline),
+		Description: optional(info.Description),
+		Instruction: optional(info.Instruction),
+		Web:         optional(info.Web),
+		Contact:     optional(info.Contact),
+		Parameter:   toKeyValues(info.Parameter),
+	}
+	for _, category := range info.Category {
+		num, err := enumNumber(Category(0).Descriptor(), "CATEGORY_", info.CodeText(category))
+		if err != nil {
+			return nil, err
+		}
+		msg.Category = append(msg.Category, Category(num))
+	}
+	for _, responseType := range info.ResponseType {
+		num, err := enumNumber(ResponseType(0).Descriptor(), "RESPONSE_TYPE_", info.CodeText(responseType))
+		if err != nil {
+			return nil, err
+		}
+		msg.ResponseType = append(msg.ResponseType, ResponseType(num))
+	}
+	num, err := enumNumber(msg.Urgency.Descriptor(), "URGENCY_", info.CodeText(info.Urgency))
+	if err != nil {
+		return nil, err
+	}
+	msg.Urgency = Urgency(num)
+	if num, err = enumNumber(msg.Severity.Descriptor(), "SEVERITY_", info.CodeText(info.Severity)); err != nil {
+		return nil, err
+	}
+	msg.Severity = Severity(num)
+	if num, err = enumNumber(msg.Certainty.Descriptor(), "CERTAINTY_", info.CodeText(info.Certainty)); err != nil {
+		return nil, err
+	}
+	msg.Certainty = Certainty(num)
+
+	for _, res := range info.Resource {
+		out := &Resource{
+			ResourceDesc: res.ResourceDesc,
+			MimeType:     res.MimeType,
+			Uri:          optional(res.URI),
+			DerefUri:     optional(res.DerefURI),
+			Digest:       optional(res.Digest),
+		}
+		if res.Size != 0 {
+			size := int64(res.Size)
+			out.Size = &size
+		}
+		msg.Resource = append(msg.Resource, out)
+	}
+	for i := range info.Area {
+		area := &info.Area[i]
+		out := &Area{
+			AreaDesc: area.AreaDesc,
+			Geocode:  toKeyValues(area.Geocode),
+		}
+		points, err := area.PolygonPoints()
+		if err != nil {
+			return nil, err
+		}
+		if len(points) > 0 {
+			out.Polygon = &Polygon{}
+			for _, point := range points {
+				out.Polygon.Points = append(out.Polygon.Points, toPoint(point))
+			}
+		}
+		circles, err := area.Circles()
+		if err != nil {
+			return nil, err
+		}
+		for _, circle := range circles {
+			out.Circle = append(out.Circle, &Circle{Center: toPoint(circle.Center), Radius: circle.Radius})
+		}
+		if area.Altitude != 0 {
+			altitude := area.Altitude
+			out.Altitude = &altitude
+		}
+		if area.Ceiling != 0 {
+			ceiling := area.Ceiling
+			out.Ceiling = &ceiling
+		}
+		msg.Area = append(msg.Area, out)
+	}
+	return msg, nil
+}
+
+// FromProto returns the Alert of the message. If the message has an
+// unspecified or unknown code, or a dateTime that does not conform to CAP 1.2,
+// an error is returned.
+func FromProto(msg *Alert) (*cap.Alert, error) {
+	sent, err := parseTime(msg.GetSent())
+	if err != nil {
+		return nil, err
+	}
+	alert := &cap.Alert{
+		XMLName:     xml.Name{Space: capNamespace, Local: "alert"},
+		Identifier:  msg.GetIdentifier(),
+		Sender:      msg.GetSender(),
+		Sent:        sent,
+		Source:      msg.GetSource(),
+		Restriction: msg.GetRestriction(),
+		Addresses:   cap.NewList(msg.GetAddresses()...),
+		Code:        msg.GetCode(),
+		Note:        msg.GetNote(),
+		References:  cap.NewList(msg.GetReferences()...),
+		Incidents:   cap.NewList(msg.GetIncidents()...),
+	}
+	text, err := codeText(msg.GetStatus().Descriptor(), "STATUS_", protoreflect.EnumNumber(msg.GetStatus()), cap.StatusMapping)
+	if err != nil {
+		return nil, err
+	}
+	alert.Status = cap.StatusMapping[text]
+	if text, err = codeText(msg.GetMsgType().Descriptor(), "MSG_TYPE_", protoreflect.EnumNumber(msg.GetMsgType()), cap.MsgTypeMapping); err != nil {
+		return nil, err
+	}
+	alert.MsgType = cap.MsgTypeMapping[text]
+	if text, err = codeText(msg.GetScope().Descriptor(), "SCOPE_", protoreflect.EnumNumber(msg.GetScope()), cap.ScopeMapping); err != nil {
+		return nil, err
+	}
+	alert.Scope = cap.ScopeMapping[text]
+	for _, info := range msg.GetInfo() {
+		out, err := fromInfo(info)
+		if err != nil {
+			return nil, err
+		}
+		alert.Info = append(alert.Info, out)
+	}
+	for _, sig := range msg.GetSignature() {
+		signedInfo := sig.GetSignedInfo()
+		reference := signedInfo.GetReference()
+		out := cap.Signature{
+			XMLName: xml.Name{Space: "http://www.w3.org/2000/09/xmldsig#", Local: "Signature"},
+			ID:      sig.GetId(),
+			SignedInfo: cap.SignedInfo{
+				CanonicalizationMethod: cap.Algorithm{Algorithm: signedInfo.GetCanonicalizationMethod()},
+				SignatureMethod:        cap.Algorithm{Algorithm: signedInfo.GetSignatureMethod()},
+				Reference: cap.Reference{
+					URI:          reference.GetUri(),
+					Transform:    cap.Algorithm{Algorithm: reference.GetTransform()},
+					DigestMethod: cap.Algorithm{Algorithm: reference.GetDigestMethod()},
+					DigestValue:  reference.GetDigestValue(),
+				},
+			},
+			SignatureValue:  sig.GetSignatureValue(),
+			X509Certificate: sig.GetX509Certificate(),
+		}
+		for _, prop := range sig.GetSignatureProperties() {
+			out.SignatureProperties = append(out.SignatureProperties, cap.SignatureProperty{
+				ID:      prop.GetId(),
+				Target:  prop.GetTarget(),
+				XCValue: cap.XCValue{XC: prop.GetXc()},
+			})
+		}
+		alert.Signature = append(alert.Signature, out)
+	}
+	return alert, nil
+}
+
+// fromKeyValues returns the key-value pairs of the messages.
+func fromKeyValues(msgs []*KeyValue) []cap.KeyValue {
+	var pairs []cap.KeyValue
+	for _, msg := range msgs {
+		pairs = append(pairs, cap.KeyValue{ValueName: msg.GetValueName(), Value: msg.GetValue()})
+	}
+	return pairs
+}
+
+// fromPoint returns the Point of the message.
+func fromPoint(msg *Point) cap.Point {
+	return cap.Point{Latitude: msg.GetLatitude(), Longitude: msg.GetLongitude()}
+}
+
+// fromInfo returns the Info of the message.
+func fromInfo(msg *Info) (cap.Info, error) {
+	info := cap.Info{
+		XMLName:     xml.Name{Space: capNamespace, Local: "info"},
+		Language:    msg.GetLanguage(),
+		Event:       msg.GetEvent(),
+		Audience:    msg.GetAudience(),
+		EventCode:   fromKeyValues(msg.GetEventCode()),
+		SenderName:  msg.GetSenderName(),
+		Headline:    msg.GetHeadline(),
+		Description: msg.GetDescription(),
+		Instruction: msg.GetInstruction(),
+		Web:         msg.GetWeb(),
+		Contact:     msg.GetContact(),
+		Parameter:   fromKeyValues(msg.GetParameter()),
+	}
+	var err error
+	if info.Effective, err = parseTime(msg.GetEffective()); err != nil {
+		return info, err
+	}
+	if info.Onset, err = parseTime(msg.GetOnset()); err != nil {
+		return info, err
+	}
+	if info.Expires, err = parseTime(msg.GetExpires()); err != nil {
+		return info, err
+	}
+	for _, category := range msg.GetCategory() {
+		text, err := codeText(category.Descriptor(), "CATEGORY_", protoreflect.EnumNumber(category), cap.CategoryMapping)
+		if err != nil {
+			return info, err
+		}
+		info.Category = append(info.Category, cap.CategoryMapping[text])
+	}
+	for _, responseType := range msg.GetResponseType() {
+		text, err := codeText(responseType.Descriptor(), "RESPONSE_TYPE_", protoreflect.EnumNumber(responseType), cap.ResponseTypeMapping)
+		if err != nil {
+			return info, err
+		}
+		info.ResponseType = append(info.ResponseType, cap.ResponseTypeMapping[text])
+	}
+	text, err := codeText(msg.GetUrgency().Descriptor(), "URGENCY_", protoreflect.EnumNumber(msg.GetUrgency()), cap.UrgencyMapping)
+	if err != nil {
+		return info, err
+	}
+	info.Urgency = cap.UrgencyMapping[text]
+	if text, err = codeText(msg.GetSeverity().Descriptor(), "SEVERITY_", protoreflect.EnumNumber(msg.GetSeverity()), cap.SeverityMapping); err != nil {
+		return info, err
+	}
+	info.Severity = cap.SeverityMapping[text]
+	if text, err = codeText(msg.GetCertainty().Descriptor(), "CERTAINTY_", protoreflect.EnumNumber(msg.GetCertainty()), cap.CertaintyMapping); err != nil {
+		return info, err
+	}
+	info.Certainty = cap.CertaintyMapping[text]
+
+	for _, res := range msg.GetResource() {
+		info.Resource = append(info.Resource, cap.Resource{
+			XMLName:      xml.Name{Space: capNamespace, Local: "resource"},
+			ResourceDesc: res.GetResourceDesc(),
+			MimeType:     res.GetMimeType(),
+			Size:         int(res.GetSize()),
+			URI:          res.GetUri(),
+			DerefURI:     res.GetDerefUri(),
+			Digest:       res.GetDigest(),
+		})
+	}
+	for _, area := range msg.GetArea() {
+		out := cap.Area{
+			XMLName:  xml.Name{Space: capNamespace, Local: "area"},
+			AreaDesc: area.GetAreaDesc(),
+			Geocode:  fromKeyValues(area.GetGeocode()),
+			Altitude: area.GetAltitude(),
+			Ceiling:  area.GetCeiling(),
+		}
+		var points []string
+		for _, point := range area.GetPolygon().GetPoints() {
+			points = append(points, fromPoint(point).String())
+		}
+		out.Polygon = cap.NewList(points...)
+		for _, circle := range area.GetCircle() {
+			out.Circle = append(out.Circle, cap.Circle{Center: fromPoint(circle.GetCenter()), Radius: circle.GetRadius()}.String())
+		}
+		info.Area = append(info.Area, out)
+	}
+	return info, nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cappb_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/thetannerryan/cap"
+	"github.com/thetannerryan/cap/cappb"
+	"google.golang.org/protobuf/proto"
+)
+
+// test is a helper for the tests.
+func test(t *testing.T, name, expected, actual string) {
+	fmt.Printf(">> Testing %s\nExpected: %s\nActual:   %s\n", name, expected, actual)
+	if expected != actual {
+		t.Errorf("Incorrect output")
+	}
+}
+
+// TestRoundTrip tests that every example alert converts to a message and back
+// to the same XML.
+func TestRoundTrip(t *testing.T) {
+	names, err := filepath.Glob("../testing/*.xml")
+	if err != nil {
+		panic(err)
+	}
+	for _, name := range names {
+		contents, err := ioutil.ReadFile(name)
+		if err != nil {
+			panic(err)
+		}
+		alert, err := cap.ParseCAP(contents)
+		if err != nil {
+			panic(err)
+		}
+		msg, err := cappb.ToProto(alert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := proto.Marshal(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := &cappb.Alert{}
+		if err := proto.Unmarshal(data, decoded); err != nil {
+			t.Fatal(err)
+		}
+		test(t, "Message equal "+filepath.Base(name), "true", fmt.Sprint(proto.Equal(msg, decoded)))
+		parsed, err := cappb.FromProto(decoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, _ := xml.Marshal(alert)
+		actual, _ := xml.Marshal(parsed)
+		test(t, "Round trip "+filepath.Base(name), string(expected), string(actual))
+	}
+}
+
+// TestPresence tests that only the elements set in an Alert are present in its
+// message.
+func TestPresence(t *testing.T) {
+	contents, err := ioutil.ReadFile("../testing/Oasis_ThunderstormWarning.xml")
+	if err != nil {
+		panic(err)
+	}
+	alert, err := cap.ParseCAP(contents)
+	if err != nil {
+		panic(err)
+	}
+	msg, err := cappb.ToProto(alert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := msg.GetInfo()[0]
+	test(t, "Source absent", "false", fmt.Sprint(msg.Source != nil))
+	test(t, "Expires present", "2003-06-17T16:00:00-07:00", info.GetExpires())
+	test(t, "Effective absent", "false", fmt.Sprint(info.Effective != nil))
+	test(t, "Category", "[CATEGORY_MET]", fmt.Sprint(info.GetCategory()))
+	test(t, "Response type", "[RESPONSE_TYPE_SHELTER]", fmt.Sprint(info.GetResponseType()))
+	test(t, "Polygon points", "5", fmt.Sprint(len(info.GetArea()[0].GetPolygon().GetPoints())))
+	test(t, "Altitude absent", "false", fmt.Sprint(info.GetArea()[0].Altitude != nil))
+
+	msg.Status = cappb.Status_STATUS_UNSPECIFIED
+	_, err = cappb.FromProto(msg)
+	test(t, "Unspecified status", "Error: illegal value 0 for Status code", fmt.Sprint(err))
+	msg.Status = cappb.Status_STATUS_ACTUAL
+	msg.Sent = "2003-06-17T14:57:00Z"
+	_, err = cappb.FromProto(msg)
+	test(t, "Bad sent", "Error: illegal value 2003-06-17T14:57:00Z for DateTime", fmt.Sprint(err))
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package capserver receives CAP alerts pushed by HTTP POST.
+
+A Handler accepts application/cap+xml and application/xml request bodies, then
+parses and validates each alert. If a Verifier is set, it checks the signature
+of the alert. The alert is then passed to the Receive function. The response is
+a CAP message addressed to the sender of the alert: an Ack referencing the
+received alert, or an Error whose note explains why the alert was rejected. An
+alert that cannot be parsed, or whose sender is unknown, cannot be answered
+privately, so it is rejected with a plain text response rather than a public
+CAP message.
+
+	handler := capserver.NewHandler("cap@example.org", func(ctx context.Context, alert *cap.Alert) error {
+		return store.Save(ctx, alert)
+	})
+	handler.MaxBytes = 4 << 20
+	http.Handle("/cap", handler)
+*/
+package capserver
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/thetannerryan/cap"
+)
+
+// DefaultMaxBytes is the size limit of request bodies if Handler.MaxBytes is
+// not set.
+const DefaultMaxBytes = 1 << 20
+
+// Verifier checks the signature of a received alert. The data is the request
+// body the alert was parsed from.
+type Verifier interface {
+	Verify(data []byte, alert *cap.Alert) error
+}
+
+// VerifierFunc adapts a function to a Verifier.
+type VerifierFunc func(data []byte, alert *cap.Alert) error
+
+// Verify calls f(data, alert).
+func (f VerifierFunc) Verify(data []byte, alert *cap.Alert) error {
+	return f(data, alert)
+}
+
+// ReceiveFunc is called with each accepted alert. If it returns an error, the
+// alert is answered with a CAP Error message.
+type ReceiveFunc func(ctx context.Context, alert *cap.Alert) error
+
+// Handler is an http.Handler receiving pushed CAP alerts. Its fields must not
+// be changed while it is serving requests.
+type Handler struct {
+	// Sender is the sender of the Ack and Error replies (REQUIRED)
+	Sender string
+	// Receive is called with each accepted alert (REQUIRED)
+	Receive ReceiveFunc
+	// MaxBytes limits the size of request bodies. If zero, DefaultMaxBytes is
+	// used.
+	MaxBytes int64
+	// Options are used to parse each alert.
+	Options cap.ParseOptions
+	// Verifier checks the signature of each alert. If nil, signatures are not
+	// checked.
+	Verifier Verifier
+	// ErrorLog logs failures of the Receive function and of building replies.
+	// If nil, the standard logger of the log package is used.
+	ErrorLog *log.Logger
+}
+
+// NewHandler returns a Handler replying as the sender, and passing each
+// accepted alert to the receive function.
+func NewHandler(sender string, receive ReceiveFunc) *Handler {
+	return &Handler{Sender: sender, Receive: receive}
+}
+
+// acceptedTypes are the media types of request bodies accepted by the Handler.
+var acceptedTypes = map[string]bool{
+	"application/cap+xml": true,
+	"application/xml":     true,
+}
+
+// ServeHTTP receives an alert from the body of a POST request. The HTTP status
+// of the reply is 200 for an Ack. For an Error, it is 400 if the alert could
+// not be parsed or is invalid, 403 if its signature was rejected, and 500 if
+// the Receive function failed. The error of the Receive function is logged
+// rather than sent to the sender. An alert that cannot be answered with a
+// private Error is rejected with a plain text response instead.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || !acceptedTypes[mediaType] {
+		http.Error(w, "unsupported media type", http.StatusUnsupportedMediaType)
+		return
+	}
+	maxBytes := h.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBytes+1))
+	if err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	if int64(len(data)) > maxBytes {
+		http.Error(w, "request entity too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	alert, _, err := cap.ParseCAPWithOptions(data, h.Options)
+	if err != nil {
+		// without an alert there is no sender to address
+		h.reject(w, http.StatusBadRequest, nil, err.Error())
+		return
+	}
+	if err := alert.Validate(); err != nil {
+		h.reject(w, http.StatusBadRequest, alert, err.Error())
+		return
+	}
+	if h.Verifier != nil {
+		if err := h.Verifier.Verify(data, alert); err != nil {
+			h.reject(w, http.StatusForbidden, alert, err.Error())
+			return
+		}
+	}
+	if err := h.Receive(r.Context(), alert); err != nil {
+		h.logf("capserver: receiving %s: %v", alert.Key(), err)
+		h.reject(w, http.StatusInternalServerError, alert, "Error: alert could not be processed")
+		return
+	}
+	reply, err := alert.NewAck(h.Sender).Build()
+	h.write(w, http.StatusOK, reply, err)
+}
+
+// reject answers the alert with an Error message explaining the note. If the
+// alert is nil, or the Error cannot be addressed to its sender, the note is
+// written as plain text, as a rejection must not be published as a public
+// message.
+func (h *Handler) reject(w http.ResponseWriter, status int, alert *cap.Alert, note string) {
+	if alert == nil {
+		http.Error(w, note, status)
+		return
+	}
+	reply, err := alert.NewError(h.Sender, note).Build()
+	if err != nil {
+		http.Error(w, note, status)
+		return
+	}
+	h.write(w, status, reply, nil)
+}
+
+// write writes the reply as an XML document with the HTTP status. If the reply
+// could not be built or marshaled, the error is logged and a plain 500 response
+// is written instead.
+func (h *Handler) write(w http.ResponseWriter, status int, reply *cap.Alert, err error) {
+	var body []byte
+	if err == nil {
+		body, err = xml.MarshalIndent(reply, "", "  ")
+	}
+	if err != nil {
+		h.logf("capserver: cannot write reply: %v", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	body = append([]byte(xml.Header), body...)
+	w.Header().Set("Content-Type", "application/cap+xml; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// logf logs a failure to ErrorLog, or to the standard logger if it is nil.
+func (h *Handler) logf(format string, args ...interface{}) {
+	if h.ErrorLog != nil {
+		h.ErrorLog.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package capserver_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/thetannerryan/cap"
+	"github.com/thetannerryan/cap/capserver"
+)
+
+// test is a helper for the tests.
+func test(t *testing.T, name, expected, actual string) {
+	fmt.Printf(">> Testing %s\nExpected: %s\nActual:   %s\n", name, expected, actual)
+	if expected != actual {
+		t.Errorf("Incorrect output")
+	}
+}
+
+// fixture returns one of the example alerts.
+func fixture(name string) []byte {
+	contents, err := ioutil.ReadFile("../testing/" + name)
+	if err != nil {
+		panic(err)
+	}
+	return contents
+}
+
+// post sends the body to the handler, and returns the response and its parsed
+// CAP message, if any.
+func post(t *testing.T, handler http.Handler, contentType, body string) (*httptest.ResponseRecorder, *cap.Alert) {
+	req := httptest.NewRequest(http.MethodPost, "https://cap.example.org/cap", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "application/cap+xml") {
+		return rec, nil
+	}
+	reply, err := cap.ParseCAP(rec.Body.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rec, reply
+}
+
+// TestHandler tests receiving alerts, and the Ack and Error replies.
+func TestHandler(t *testing.T) {
+	var received []*cap.Alert
+	var failure error
+	handler := capserver.NewHandler("cap@example.org", func(ctx context.Context, alert *cap.Alert) error {
+		if failure != nil {
+			return failure
+		}
+		received = append(received, alert)
+		return nil
+	})
+	thunderstorm := string(fixture("Oasis_ThunderstormWarning.xml"))
+
+	rec, reply := post(t, handler, "application/cap+xml", thunderstorm)
+	test(t, "Ack status", "200", fmt.Sprint(rec.Code))
+	test(t, "Received", "1", fmt.Sprint(len(received)))
+	test(t, "Ack type", "Ack", reply.MsgType.String())
+	test(t, "Ack sender", "cap@example.org", reply.Sender)
+	test(t, "Ack references", "KSTO@NWS.NOAA.GOV,KSTO1055887203,2003-06-17T14:57:00-07:00", reply.References.String())
+	test(t, "Ack scope", "Private", reply.Scope.String())
+
+	rec, _ = post(t, handler, "application/xml; charset=utf-8", thunderstorm)
+	test(t, "Charset status", "200", fmt.Sprint(rec.Code))
+
+	rec, _ = post(t, handler, "text/plain", thunderstorm)
+	test(t, "Media type status", "415", fmt.Sprint(rec.Code))
+
+	rec, reply = post(t, handler, "application/cap+xml", "<alert>")
+	test(t, "Malformed status", "400", fmt.Sprint(rec.Code))
+	test(t, "Malformed reply", "<nil>", fmt.Sprint(reply))
+	test(t, "Malformed content type", "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	test(t, "Malformed body", "true", fmt.Sprint(strings.HasPrefix(rec.Body.String(), "expected element <alert>")))
+
+	invalid := strings.Replace(thunderstorm, "<event>SEVERE THUNDERSTORM</event>", "<event></event>", 1)
+	rec, reply = post(t, handler, "application/cap+xml", invalid)
+	test(t, "Invalid status", "400", fmt.Sprint(rec.Code))
+	test(t, "Invalid note", "Error: missing required element at alert/info[1]/event", reply.Note)
+	test(t, "Invalid references", "KSTO@NWS.NOAA.GOV,KSTO1055887203,2003-06-17T14:57:00-07:00", reply.References.String())
+	test(t, "Invalid scope", "Private", reply.Scope.String())
+
+	handler.Verifier = capserver.VerifierFunc(func(data []byte, alert *cap.Alert) error {
+		return errors.New("Error: unsigned alert")
+	})
+	rec, reply = post(t, handler, "application/cap+xml", thunderstorm)
+	test(t, "Unverified status", "403", fmt.Sprint(rec.Code))
+	test(t, "Unverified note", "Error: unsigned alert", reply.Note)
+	handler.Verifier = nil
+
+	var logged bytes.Buffer
+	handler.ErrorLog = log.New(&logged, "", 0)
+	failure = errors.New("Error: storage unavailable")
+	rec, reply = post(t, handler, "application/cap+xml", thunderstorm)
+	test(t, "Receive failure status", "500", fmt.Sprint(rec.Code))
+	test(t, "Receive failure type", "Error", reply.MsgType.String())
+	test(t, "Receive failure note", "Error: alert could not be processed", reply.Note)
+	test(t, "Receive failure scope", "Private", reply.Scope.String())
+	test(t, "Receive failure log", "capserver: receiving KSTO@NWS.NOAA.GOV,KSTO1055887203,2003-06-17T21:57:00-00:00: Error: storage unavailable\n", logged.String())
+	failure = nil
+
+	handler.MaxBytes = 64
+	rec, _ = post(t, handler, "application/cap+xml", thunderstorm)
+	test(t, "Too large status", "413", fmt.Sprint(rec.Code))
+	test(t, "Received total", "2", fmt.Sprint(len(received)))
+}
+
+// TestHandlerScope tests that replies are addressed privately to the sender of
+// the alert, whatever its scope, and that alerts which cannot be answered that
+// way receive a plain text response rather than a public CAP Error.
+func TestHandlerScope(t *testing.T) {
+	handler := capserver.NewHandler("cap@example.org", func(ctx context.Context, alert *cap.Alert) error {
+		return nil
+	})
+	thunderstorm := string(fixture("Oasis_ThunderstormWarning.xml"))
+	reference := "KSTO@NWS.NOAA.GOV,KSTO1055887203,2003-06-17T14:57:00-07:00"
+
+	private := strings.Replace(thunderstorm, "<scope>Public</scope>", "<scope>Private</scope><addresses>county@example.org</addresses>", 1)
+	rec, reply := post(t, handler, "application/cap+xml", private)
+	test(t, "Private status", "200", fmt.Sprint(rec.Code))
+	test(t, "Private scope", "Private", reply.Scope.String())
+	test(t, "Private addresses", "KSTO@NWS.NOAA.GOV", reply.Addresses.String())
+
+	private = strings.Replace(thunderstorm, "<scope>Public</scope>", "<scope>Private</scope>", 1)
+	rec, reply = post(t, handler, "application/cap+xml", private)
+	test(t, "Unaddressed status", "400", fmt.Sprint(rec.Code))
+	test(t, "Unaddressed type", "Error", reply.MsgType.String())
+	test(t, "Unaddressed note", "Error: missing required element at alert/addresses", reply.Note)
+	test(t, "Unaddressed references", reference, reply.References.String())
+	test(t, "Unaddressed addresses", "KSTO@NWS.NOAA.GOV", reply.Addresses.String())
+
+	restricted := strings.Replace(thunderstorm, "<scope>Public</scope>", "<scope>Restricted</scope>", 1)
+	rec, reply = post(t, handler, "application/cap+xml", restricted)
+	test(t, "Restricted status", "400", fmt.Sprint(rec.Code))
+	test(t, "Restricted note", "Error: missing required element at alert/restriction", reply.Note)
+	test(t, "Restricted references", reference, reply.References.String())
+	test(t, "Restricted scope", "Private", reply.Scope.String())
+
+	anonymous := strings.Replace(thunderstorm, "<sender>KSTO@NWS.NOAA.GOV</sender>", "<sender></sender>", 1)
+	rec, reply = post(t, handler, "application/cap+xml", anonymous)
+	test(t, "Anonymous status", "400", fmt.Sprint(rec.Code))
+	test(t, "Anonymous reply", "<nil>", fmt.Sprint(reply))
+	test(t, "Anonymous body", "Error: missing required element at alert/sender\n", rec.Body.String())
+}
@@ -7,9 +7,11 @@ package cap
 import (
 	"encoding/json"
 	"encoding/xml"
-	"errors"
+	"strings"
 )
 
+//go:generate stringer -type=Category -trimprefix=Category
+
 // Category is a code denoting the appropriate handling of the alert message
 type Category int
 
@@ -66,36 +68,60 @@ var (
 func stringToCategoryCode(t *Category, val string) error {
 	enum, ok := CategoryMapping[val]
 	if !ok {
-		return errors.New("Error: illegal value " + val + " for Category code")
+		return illegalValue(val, "Category")
 	}
 	*t = enum
 	return nil
 }
 
-// String converts the Category code back to a string.
-func (t Category) String() string {
-	for key, val := range CategoryMapping {
-		if val == t {
-			return key
+// foldCategoryCode will perform the mapping of string to a Category code
+// without regard to case or surrounding whitespace.
+func foldCategoryCode(val string) (int, bool) {
+	val = strings.TrimSpace(val)
+	for key, enum := range CategoryMapping {
+		if strings.EqualFold(key, val) {
+			return int(enum), true
 		}
 	}
-	// logically never reached
-	return ""
+	return 0, false
+}
+
+// code returns the number of the Category code.
+func (t Category) code() int {
+	return int(t)
+}
+
+// validate returns an error if the Category is not one of the defined codes.
+func (t Category) validate() error {
+	if t < 0 || int(t) >= len(_Category_index)-1 {
+		return illegalValue(t.String(), "Category")
+	}
+	return nil
 }
 
 // UnmarshalXML will be used during the XML unmarshaling for conversion to
 // Category code.
 func (t *Category) UnmarshalXML(decoder *xml.Decoder, elem xml.StartElement) error {
+	offset := decoder.InputOffset()
 	var val string
 	if err := decoder.DecodeElement(&val, &elem); err != nil {
 		return err
 	}
-	return stringToCategoryCode(t, val)
+	err := stringToCategoryCode(t, val)
+	if err == nil {
+		return nil
+	}
+	code, ok := lenientCode(decoder, elem, offset, val, foldCategoryCode)
+	if !ok {
+		return atOffset(err, offset)
+	}
+	*t = Category(code)
+	return nil
 }
 
 // MarshalXML converts the Category code back to a string when marshaling XML.
 func (t Category) MarshalXML(encoder *xml.Encoder, elem xml.StartElement) error {
-	return encoder.EncodeElement(t.String(), elem)
+	return marshalCode(encoder, elem, t)
 }
 
 // UnmarshalJSON will be used during the JSON unmarshaling for conversion to
@@ -110,5 +136,8 @@ func (t *Category) UnmarshalJSON(buff []byte) error {
 
 // MarshalJSON converts the Category code back to a string when marshaling JSON.
 func (t Category) MarshalJSON() ([]byte, error) {
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
 	return json.Marshal(t.String())
 }
@@ -7,10 +7,16 @@ package cap
 import (
 	"encoding/json"
 	"encoding/xml"
-	"errors"
+	"strings"
 )
 
-// Certainty is a code denoting the appropriate handling of the alert message
+//go:generate stringer -type=Certainty -trimprefix=Certainty
+
+// Certainty is a code denoting the appropriate handling of the alert message.
+//
+// Certainty codes are declared from most to least certain, followed by
+// CertaintyUnknown. Use Rank or Compare to order them, rather than the
+// underlying value.
 type Certainty int
 
 const (
@@ -42,36 +48,89 @@ var (
 func stringToCertaintyCode(t *Certainty, val string) error {
 	enum, ok := CertaintyMapping[val]
 	if !ok {
-		return errors.New("Error: illegal value " + val + " for Certainty code")
+		return illegalValue(val, "Certainty")
 	}
 	*t = enum
 	return nil
 }
 
-// String converts the Certainty code back to a string.
-func (t Certainty) String() string {
-	for key, val := range CertaintyMapping {
-		if val == t {
-			return key
+// foldCertaintyCode will perform the mapping of string to a Certainty code
+// without regard to case or surrounding whitespace.
+func foldCertaintyCode(val string) (int, bool) {
+	val = strings.TrimSpace(val)
+	for key, enum := range CertaintyMapping {
+		if strings.EqualFold(key, val) {
+			return int(enum), true
 		}
 	}
-	// logically never reached
-	return ""
+	return 0, false
+}
+
+// code returns the number of the Certainty code.
+func (t Certainty) code() int {
+	return int(t)
+}
+
+// validate returns an error if the Certainty is not one of the defined codes.
+func (t Certainty) validate() error {
+	if t < 0 || int(t) >= len(_Certainty_index)-1 {
+		return illegalValue(t.String(), "Certainty")
+	}
+	return nil
+}
+
+// Rank returns the importance of the Certainty code, from 4 for
+// CertaintyObserved down to 1 for CertaintyUnlikely. CertaintyUnknown, and any
+// value outside of the defined codes, ranks lowest at 0.
+func (t Certainty) Rank() int {
+	switch t {
+	case CertaintyObserved:
+		return 4
+	case CertaintyLikely:
+		return 3
+	case CertaintyPossible:
+		return 2
+	case CertaintyUnlikely:
+		return 1
+	}
+	return 0
+}
+
+// Compare returns 1 if the Certainty code is more certain than the other, -1 if
+// it is less certain, and 0 if they rank equally.
+func (t Certainty) Compare(other Certainty) int {
+	switch {
+	case t.Rank() > other.Rank():
+		return 1
+	case t.Rank() < other.Rank():
+		return -1
+	}
+	return 0
 }
 
 // UnmarshalXML will be used during the XML unmarshaling for conversion to
 // Certainty code.
 func (t *Certainty) UnmarshalXML(decoder *xml.Decoder, elem xml.StartElement) error {
+	offset := decoder.InputOffset()
 	var val string
 	if err := decoder.DecodeElement(&val, &elem); err != nil {
 		return err
 	}
-	return stringToCertaintyCode(t, val)
+	err := stringToCertaintyCode(t, val)
+	if err == nil {
+		return nil
+	}
+	code, ok := lenientCode(decoder, elem, offset, val, foldCertaintyCode)
+	if !ok {
+		return atOffset(err, offset)
+	}
+	*t = Certainty(code)
+	return nil
 }
 
 // MarshalXML converts the Certainty code back to a string when marshaling XML.
 func (t Certainty) MarshalXML(encoder *xml.Encoder, elem xml.StartElement) error {
-	return encoder.EncodeElement(t.String(), elem)
+	return marshalCode(encoder, elem, t)
 }
 
 // UnmarshalJSON will be used during the JSON unmarshaling for conversion to
@@ -87,5 +146,8 @@ func (t *Certainty) UnmarshalJSON(buff []byte) error {
 // MarshalJSON converts the Certainty code back to a string when marshaling
 // JSON.
 func (t Certainty) MarshalJSON() ([]byte, error) {
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
 	return json.Marshal(t.String())
 }
@@ -0,0 +1,346 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/thetannerryan/cap"
+)
+
+const (
+	// circleSegments is the number of sides of the polygons approximating
+	// circles.
+	circleSegments = 32
+	// kmlNamespace is the namespace of KML 2.2 documents.
+	kmlNamespace = "http://www.opengis.net/kml/2.2"
+)
+
+// formats maps each output format to its writer.
+var formats = map[string]func(alert *cap.Alert) ([]byte, error){
+	"xml":     toXML,
+	"json":    cap.MarshalJSON,
+	"geojson": toGeoJSON,
+	"kml":     toKML,
+}
+
+// convert implements the convert command.
+func convert(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := newFlags("convert", "[-to json|xml|geojson|kml] [file]", stderr)
+	to := flags.String("to", "json", "output `format`: json, xml, geojson or kml")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	format, ok := formats[strings.ToLower(*to)]
+	if !ok {
+		fmt.Fprintf(stderr, "capctl: unknown format %q\n", *to)
+		return exitUsage
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return exitUsage
+	}
+	inputs, err := readInputs(flags.Args(), stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, "capctl:", err)
+		return exitFail
+	}
+	alert, _, err := parseAlert(inputs[0].data, cap.ParseOptions{})
+	if err != nil {
+		fmt.Fprintf(stderr, "capctl: %s: %s\n", inputs[0].name, err)
+		return exitFail
+	}
+	data, err := format(alert)
+	if err != nil {
+		fmt.Fprintf(stderr, "capctl: %s: %s\n", inputs[0].name, err)
+		return exitFail
+	}
+	fmt.Fprintf(stdout, "%s\n", data)
+	return exitOK
+}
+
+// toXML returns the XML form of the alert.
+func toXML(alert *cap.Alert) ([]byte, error) {
+	data, err := xml.MarshalIndent(alert, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// shape is a polygon ring, or a single point for a circle of no radius.
+type shape []cap.Point
+
+// areaShapes returns the polygon and circles of the area as shapes. Circles
+// are approximated by polygons of circleSegments sides.
+func areaShapes(area *cap.Area) ([]shape, error) {
+	var shapes []shape
+	polygon, err := area.PolygonPoints()
+	if err != nil {
+		return nil, err
+	}
+	if len(polygon) > 0 {
+		shapes = append(shapes, polygon)
+	}
+	circles, err := area.Circles()
+	if err != nil {
+		return nil, err
+	}
+	for _, circle := range circles {
+		shapes = append(shapes, circleRing(circle))
+	}
+	return shapes, nil
+}
+
+// circleRing returns a closed ring approximating the circle, or its center
+// alone if it has no radius.
+func circleRing(c cap.Circle) shape {
+	if c.Radius == 0 {
+		return shape{c.Center}
+	}
+	ring := c.Polygon(circleSegments)
+	for i := range ring {
+		ring[i] = cap.Point{Latitude: round(ring[i].Latitude), Longitude: round(ring[i].Longitude)}
+	}
+	return ring
+}
+
+// round rounds a coordinate to six decimal places, about 0.1 meters.
+func round(deg float64) float64 {
+	return math.Round(deg*1e6) / 1e6
+}
+
+// geoFeatureCollection is a GeoJSON FeatureCollection.
+type geoFeatureCollection struct {
+	Type     string       `json:"type"`
+	Features []geoFeature `json:"features"`
+}
+
+// geoFeature is a GeoJSON Feature for a single area of an info block.
+type geoFeature struct {
+	Type       string        `json:"type"`
+	Geometry   *geoGeometry  `json:"geometry"`
+	Properties geoProperties `json:"properties"`
+}
+
+// geoGeometry is a GeoJSON geometry.
+type geoGeometry struct {
+	Type        string        `json:"type"`
+	Coordinates interface{}   `json:"coordinates,omitempty"`
+	Geometries  []geoGeometry `json:"geometries,omitempty"`
+}
+
+// geoProperties are the properties of a GeoJSON Feature.
+type geoProperties struct {
+	Identifier string         `json:"identifier"`
+	Sender     string         `json:"sender"`
+	Sent       string         `json:"sent"`
+	Status     string         `json:"status"`
+	MsgType    string         `json:"msgType"`
+	Language   string         `json:"language,omitempty"`
+	Event      string         `json:"event"`
+	Headline   string         `json:"headline,omitempty"`
+	Urgency    string         `json:"urgency"`
+	Severity   string         `json:"severity"`
+	Certainty  string         `json:"certainty"`
+	Effective  string         `json:"effective,omitempty"`
+	Onset      string         `json:"onset,omitempty"`
+	Expires    string         `json:"expires,omitempty"`
+	AreaDesc   string         `json:"areaDesc"`
+	Geocode    []cap.KeyValue `json:"geocode,omitempty"`
+}
+
+// position returns the GeoJSON position of the point.
+func position(p cap.Point) []float64 {
+	return []float64{p.Longitude, p.Latitude}
+}
+
+// shapeGeometry returns the GeoJSON Polygon or Point of the shape.
+func shapeGeometry(s shape) geoGeometry {
+	if len(s) == 1 {
+		return geoGeometry{Type: "Point", Coordinates: position(s[0])}
+	}
+	ring := make([][]float64, len(s))
+	for i, p := range s {
+		ring[i] = position(p)
+	}
+	return geoGeometry{Type: "Polygon", Coordinates: [][][]float64{ring}}
+}
+
+// areaGeometry returns the GeoJSON geometry of the shapes of an area, or nil
+// if it has none.
+func areaGeometry(shapes []shape) *geoGeometry {
+	if len(shapes) == 0 {
+		return nil
+	}
+	geometries := make([]geoGeometry, len(shapes))
+	polygons := make([]interface{}, len(shapes))
+	multi := true
+	for i, s := range shapes {
+		geometries[i] = shapeGeometry(s)
+		polygons[i] = geometries[i].Coordinates
+		multi = multi && geometries[i].Type == "Polygon"
+	}
+	switch {
+	case len(geometries) == 1:
+		return &geometries[0]
+	case multi:
+		return &geoGeometry{Type: "MultiPolygon", Coordinates: polygons}
+	}
+	return &geoGeometry{Type: "GeometryCollection", Geometries: geometries}
+}
+
+// dateTimeText returns the text of a dateTime, or an empty string if unset.
+func dateTimeText(t cap.DateTime) string {
+	if t.Time().IsZero() {
+		return ""
+	}
+	return t.String()
+}
+
+// toGeoJSON returns a GeoJSON FeatureCollection with a Feature for each area
+// of each info block. Areas without geometry have a null geometry.
+func toGeoJSON(alert *cap.Alert) ([]byte, error) {
+	doc := geoFeatureCollection{Type: "FeatureCollection", Features: []geoFeature{}}
+	for i := range alert.Info {
+		info := &alert.Info[i]
+		for j := range info.Area {
+			area := &info.Area[j]
+			shapes, err := areaShapes(area)
+			if err != nil {
+				return nil, err
+			}
+			doc.Features = append(doc.Features, geoFeature{
+				Type:     "Feature",
+				Geometry: areaGeometry(shapes),
+				Properties: geoProperties{
+					Identifier: alert.Identifier,
+					Sender:     alert.Sender,
+					Sent:       dateTimeText(alert.Sent),
+					Status:     alert.CodeText(alert.Status),
+					MsgType:    alert.CodeText(alert.MsgType),
+					Language:   info.Language,
+					Event:      info.Event,
+					Headline:   info.Headline,
+					Urgency:    info.CodeText(info.Urgency),
+					Severity:   info.CodeText(info.Severity),
+					Certainty:  info.CodeText(info.Certainty),
+					Effective:  dateTimeText(info.Effective),
+					Onset:      dateTimeText(info.Onset),
+					Expires:    dateTimeText(info.Expires),
+					AreaDesc:   area.AreaDesc,
+					Geocode:    area.Geocode,
+				},
+			})
+		}
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// kmlDocument is a KML document.
+type kmlDocument struct {
+	XMLName    xml.Name       `xml:"kml"`
+	Namespace  string         `xml:"xmlns,attr"`
+	Name       string         `xml:"Document>name"`
+	Placemarks []kmlPlacemark `xml:"Document>Placemark"`
+}
+
+// kmlPlacemark is a KML Placemark for a single area of an info block.
+type kmlPlacemark struct {
+	Name          string            `xml:"name"`
+	Description   string            `xml:"description,omitempty"`
+	Begin         string            `xml:"TimeSpan>begin,omitempty"`
+	End           string            `xml:"TimeSpan>end,omitempty"`
+	Data          []kmlData         `xml:"ExtendedData>Data"`
+	MultiGeometry *kmlMultiGeometry `xml:"MultiGeometry,omitempty"`
+}
+
+// kmlData is a named value within the ExtendedData of a Placemark.
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+// kmlMultiGeometry holds the polygons and points of a Placemark.
+type kmlMultiGeometry struct {
+	Polygons []kmlCoordinates `xml:"Polygon"`
+	Points   []kmlCoordinates `xml:"Point"`
+}
+
+// kmlCoordinates is a Polygon with an outer boundary, or a Point.
+type kmlCoordinates struct {
+	Ring  string `xml:"outerBoundaryIs>LinearRing>coordinates,omitempty"`
+	Point string `xml:"coordinates,omitempty"`
+}
+
+// kmlText returns the KML coordinates of the points.
+func kmlText(points []cap.Point) string {
+	vals := make([]string, len(points))
+	for i, p := range points {
+		vals[i] = strconv.FormatFloat(p.Longitude, 'f', -1, 64) + "," + strconv.FormatFloat(p.Latitude, 'f', -1, 64)
+	}
+	return strings.Join(vals, " ")
+}
+
+// toKML returns a KML document with a Placemark for each area of each info
+// block, spanning the effective to expiry time of the block.
+func toKML(alert *cap.Alert) ([]byte, error) {
+	doc := kmlDocument{Namespace: kmlNamespace, Name: alert.Identifier}
+	if info := alert.MostSevereInfo(); info != nil {
+		doc.Name = info.Event
+		if info.Headline != "" {
+			doc.Name = info.Headline
+		}
+	}
+	for i := range alert.Info {
+		info := &alert.Info[i]
+		begin := dateTimeText(info.Effective)
+		if begin == "" {
+			begin = dateTimeText(alert.Sent)
+		}
+		for j := range info.Area {
+			area := &info.Area[j]
+			shapes, err := areaShapes(area)
+			if err != nil {
+				return nil, err
+			}
+			placemark := kmlPlacemark{
+				Name:        area.AreaDesc,
+				Description: info.Headline,
+				Begin:       begin,
+				End:         dateTimeText(info.Expires),
+				Data: []kmlData{
+					{Name: "identifier", Value: alert.Identifier},
+					{Name: "event", Value: info.Event},
+					{Name: "urgency", Value: info.CodeText(info.Urgency)},
+					{Name: "severity", Value: info.CodeText(info.Severity)},
+					{Name: "certainty", Value: info.CodeText(info.Certainty)},
+				},
+			}
+			if len(shapes) > 0 {
+				placemark.MultiGeometry = &kmlMultiGeometry{}
+			}
+			for _, s := range shapes {
+				if len(s) == 1 {
+					placemark.MultiGeometry.Points = append(placemark.MultiGeometry.Points, kmlCoordinates{Point: kmlText(s)})
+				} else {
+					placemark.MultiGeometry.Polygons = append(placemark.MultiGeometry.Polygons, kmlCoordinates{Ring: kmlText(s)})
+				}
+			}
+			doc.Placemarks = append(doc.Placemarks, placemark)
+		}
+	}
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
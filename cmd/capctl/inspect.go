@@ -0,0 +1,156 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thetannerryan/cap"
+)
+
+// inspect implements the inspect command.
+func inspect(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := newFlags("inspect", "[-at time] [file ...]", stderr)
+	at := flags.String("at", "", "evaluate the alerts at this `time`, rather than now")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	now, err := parseTime(*at)
+	if err != nil {
+		fmt.Fprintln(stderr, "capctl:", err)
+		return exitUsage
+	}
+	inputs, err := readInputs(flags.Args(), stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, "capctl:", err)
+		return exitFail
+	}
+	status := exitOK
+	for i, in := range inputs {
+		if i > 0 {
+			fmt.Fprintln(stdout)
+		}
+		alert, _, err := parseAlert(in.data, cap.ParseOptions{Lenient: true, TolerantDateTime: true})
+		if err != nil {
+			fmt.Fprintf(stderr, "capctl: %s: %s\n", in.name, err)
+			status = exitFail
+			continue
+		}
+		summarize(stdout, in.name, alert, now)
+	}
+	return status
+}
+
+// summarize writes a summary of the alert at time now.
+func summarize(w io.Writer, name string, alert *cap.Alert, now time.Time) {
+	line := func(indent int, label, format string, args ...interface{}) {
+		fmt.Fprintf(w, "%s%-12s%s\n", strings.Repeat("  ", indent), label, fmt.Sprintf(format, args...))
+	}
+	fmt.Fprintf(w, "%s\n", name)
+	line(1, "Identifier", "%s", alert.Identifier)
+	line(1, "Sender", "%s", alert.Sender)
+	line(1, "Sent", "%s", dateTimeText(alert.Sent))
+	line(1, "Status", "%s %s, %s", alert.CodeText(alert.Status), alert.CodeText(alert.MsgType), alert.CodeText(alert.Scope))
+	if alert.Note != "" {
+		line(1, "Note", "%s", alert.Note)
+	}
+	for _, ref := range alert.References.Values() {
+		line(1, "References", "%s", ref)
+	}
+	if len(alert.Signature) > 0 {
+		line(1, "Signatures", "%d", len(alert.Signature))
+	}
+	active := alert.ActiveInfos(now)
+	line(1, "In effect", "%s at %s", yesNo(len(active) > 0), now.Format(time.RFC3339))
+
+	for i := range alert.Info {
+		info := &alert.Info[i]
+		language := info.Language
+		if language == "" {
+			language = "en-US"
+		}
+		fmt.Fprintf(w, "  Info %d (%s): %s\n", i+1, language, info.Event)
+		if info.Headline != "" {
+			line(2, "Headline", "%s", info.Headline)
+		}
+		line(2, "Rank", "%s / %s / %s", info.CodeText(info.Urgency), info.CodeText(info.Severity), info.CodeText(info.Certainty))
+		line(2, "Window", "%s", window(alert, info))
+		inEffect := false
+		for _, other := range active {
+			inEffect = inEffect || other == info
+		}
+		line(2, "Phase", "%s, in effect: %s", info.Phase(now), yesNo(inEffect))
+		for j := range info.Area {
+			area := &info.Area[j]
+			line(2, "Area", "%s", area.AreaDesc)
+			if shape := areaSummary(area); shape != "" {
+				line(3, "", "%s", shape)
+			}
+			for _, code := range area.Geocode {
+				line(3, "", "%s = %s", code.ValueName, code.Value)
+			}
+		}
+	}
+}
+
+// window returns the time window of the info block: from its effective time,
+// or the sent time of the alert, until its expiry time.
+func window(alert *cap.Alert, info *cap.Info) string {
+	from := dateTimeText(info.Effective)
+	if from == "" {
+		from = dateTimeText(alert.Sent) + " (sent)"
+	}
+	until := dateTimeText(info.Expires)
+	if until == "" {
+		until = "no expiry"
+	}
+	text := from + " to " + until
+	if onset := dateTimeText(info.Onset); onset != "" {
+		text += ", onset " + onset
+	}
+	return text
+}
+
+// areaSummary describes the geometry of the area: its shapes, approximate size
+// and center.
+func areaSummary(area *cap.Area) string {
+	points, err := area.PolygonPoints()
+	if err != nil {
+		return "malformed polygon: " + err.Error()
+	}
+	circles, err := area.Circles()
+	if err != nil {
+		return "malformed circle: " + err.Error()
+	}
+	var parts []string
+	if len(points) > 0 {
+		parts = append(parts, "polygon of "+strconv.Itoa(len(points))+" points")
+	}
+	for _, circle := range circles {
+		parts = append(parts, "circle of "+strconv.FormatFloat(circle.Radius, 'f', -1, 64)+" km")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	if size, err := area.ApproxAreaKm2(); err == nil {
+		parts = append(parts, "about "+strconv.FormatFloat(size, 'f', 0, 64)+" km²")
+	}
+	if center, err := area.Centroid(); err == nil {
+		parts = append(parts, "centered at "+strconv.FormatFloat(center.Latitude, 'f', 4, 64)+","+strconv.FormatFloat(center.Longitude, 'f', 4, 64))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// yesNo returns "yes" or "no".
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Command capctl validates, converts, inspects and verifies CAP alerts.
+
+Usage:
+
+	capctl validate [-profile cap-cp,ipaws] [-json] [-lenient] [file ...]
+	capctl convert [-to json|xml|geojson|kml] [file]
+	capctl inspect [-at time] [file ...]
+	capctl verify -ca bundle.pem [-at time|sent] [file ...]
+
+Alerts are read from the named files, or from standard input if none are given
+or a file is named "-". Alerts may be given in the XML form of CAP 1.2 or in
+the JSON form of the cap package.
+
+validate checks each alert against CAP 1.2, including the schema types of its
+XML form, and against the selected profiles.
+A report is printed for each alert, in JSON if -json is given. The exit status
+is 1 if any alert is not valid.
+
+convert writes the alert in another form. GeoJSON and KML give one feature per
+area, with circles approximated by polygons.
+
+inspect prints a summary of each alert: its status, the time window of each
+info block and whether it is in effect, and its areas.
+
+verify checks the XML digital signatures of each alert against the
+certificate authorities of a PEM bundle. The exit status is 1 if any alert is
+unsigned or has a signature that is not valid.
+*/
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/thetannerryan/cap"
+)
+
+// Exit statuses.
+const (
+	exitOK    = 0 // Success
+	exitFail  = 1 // An alert was not valid, or could not be read
+	exitUsage = 2 // The command line was not valid
+)
+
+// usage is printed for an unknown or missing command.
+const usage = `usage: capctl <command> [flags] [file ...]
+
+commands:
+  validate  check alerts against CAP 1.2 and profiles
+  convert   convert an alert between XML, JSON, GeoJSON and KML
+  inspect   print a summary of alerts
+  verify    check the signatures of alerts
+`
+
+// commands maps each command name to its function.
+var commands = map[string]func(args []string, stdin io.Reader, stdout, stderr io.Writer) int{
+	"validate": validate,
+	"convert":  convert,
+	"inspect":  inspect,
+	"verify":   verify,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line and returns the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "capctl: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+	return command(args[1:], stdin, stdout, stderr)
+}
+
+// newFlags returns the flag set of a command, writing its errors and usage to
+// stderr.
+func newFlags(name, args string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: capctl %s %s\n", name, args)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// input is the contents of a named file, or of standard input.
+type input struct {
+	name string
+	data []byte
+}
+
+// readInputs returns the contents of the files, or of standard input if no
+// files are named or a file is named "-".
+func readInputs(names []string, stdin io.Reader) ([]input, error) {
+	if len(names) == 0 {
+		names = []string{"-"}
+	}
+	inputs := make([]input, 0, len(names))
+	for _, name := range names {
+		var data []byte
+		var err error
+		if name == "-" {
+			name = "<stdin>"
+			data, err = ioutil.ReadAll(stdin)
+		} else {
+			data, err = ioutil.ReadFile(name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, input{name: name, data: data})
+	}
+	return inputs, nil
+}
+
+// isJSON reports whether the data is in the JSON form, rather than XML.
+func isJSON(data []byte) bool {
+	data = bytes.TrimLeft(data, " \t\r\n\ufeff")
+	return len(data) > 0 && data[0] == '{'
+}
+
+// parseAlert parses an alert in the XML or JSON form.
+func parseAlert(data []byte, opts cap.ParseOptions) (*cap.Alert, []cap.Warning, error) {
+	if isJSON(data) {
+		alert, err := cap.UnmarshalJSON(data)
+		return alert, nil, err
+	}
+	return cap.ParseCAPWithOptions(data, opts)
+}
+
+// parseTime parses a CAP dateTime, or an RFC 3339 time such as
+// 2003-06-17T21:57:00Z. An empty value is the current time.
+func parseTime(val string) (time.Time, error) {
+	if val == "" {
+		return time.Now(), nil
+	}
+	if t, err := cap.ParseDateTime(val); err == nil {
+		return t.Time(), nil
+	}
+	if t, err := time.Parse(time.RFC3339, val); err == nil {
+		return t, nil
+	}
+	return time.Time{}, errors.New("Error: illegal value " + val + " for time")
+}
+
+// splitList splits a comma separated flag value, dropping empty items.
+func splitList(val string) []string {
+	var items []string
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thetannerryan/cap"
+)
+
+// test is a helper for the tests.
+func test(t *testing.T, name, expected, actual string) {
+	fmt.Printf(">> Testing %s\nExpected: %s\nActual:   %s\n", name, expected, actual)
+	if expected != actual {
+		t.Errorf("Incorrect output")
+	}
+}
+
+// fixture returns the path of an example alert.
+func fixture(name string) string {
+	return "../../testing/" + name + ".xml"
+}
+
+// capctl runs the command line with the standard input, and returns its exit
+// status and output.
+func capctl(stdin string, args ...string) (int, string) {
+	var stdout, stderr bytes.Buffer
+	status := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return status, stdout.String() + stderr.String()
+}
+
+// TestValidate tests the reports and exit status of the validate command.
+func TestValidate(t *testing.T) {
+	status, out := capctl("", "validate", fixture("Oasis_ThunderstormWarning"))
+	test(t, "Valid status", "0", fmt.Sprint(status))
+	test(t, "Valid output", fixture("Oasis_ThunderstormWarning")+": valid\n", out)
+
+	status, out = capctl("", "validate", "-json", "-profile", "cap-cp,ipaws", fixture("PelmorexNAADS_WindWarning"))
+	test(t, "Profile status", "1", fmt.Sprint(status))
+	var reports []report
+	if err := json.Unmarshal([]byte(out), &reports); err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Profile valid", "false", fmt.Sprint(reports[0].Valid))
+	test(t, "Profile errors", "3", fmt.Sprint(len(reports[0].Errors)))
+	test(t, "Profile error", "ipaws: Error: missing IPAWSv1.0 code at alert/code", reports[0].Errors[0].String())
+
+	status, out = capctl("<alert xmlns=\"urn:oasis:names:tc:emergency:cap:1.2\">\n<status>Real</status></alert>", "validate", "-")
+	test(t, "Parse status", "1", fmt.Sprint(status))
+	test(t, "Parse output", "<stdin>: not valid\n  error: Error: illegal value Real for Status code at alert/status (line 2)\n", out)
+
+	status, out = capctl("<alert xmlns=\"urn:oasis:names:tc:emergency:cap:1.2\"><status>actual</status></alert>", "validate", "-lenient")
+	test(t, "Lenient status", "1", fmt.Sprint(status))
+	test(t, "Lenient warning", "true", fmt.Sprint(strings.Contains(out, "  warning: ")))
+	test(t, "Lenient error", "true", fmt.Sprint(strings.Contains(out, "  error: Error: missing required element at alert/identifier")))
+
+	thunderstorm, err := ioutil.ReadFile(fixture("Oasis_ThunderstormWarning"))
+	if err != nil {
+		panic(err)
+	}
+	unset := strings.Replace(string(thunderstorm), "<event>", "<language></language><effective>0001-01-01T00:00:00-00:00</effective><event>", 1)
+	status, out = capctl(unset, "validate", "-")
+	test(t, "Schema status", "1", fmt.Sprint(status))
+	test(t, "Schema output", "<stdin>: not valid\n"+
+		"  error: Error: illegal value \"\" for language at alert/info[1]/language\n"+
+		"  error: Error: illegal value \"0001-01-01T00:00:00-00:00\" for effective at alert/info[1]/effective\n", out)
+
+	status, _ = capctl("", "validate", "-profile", "eu-alert")
+	test(t, "Unknown profile", "2", fmt.Sprint(status))
+	status, _ = capctl("", "unknown")
+	test(t, "Unknown command", "2", fmt.Sprint(status))
+}
+
+// TestConvert tests conversion between the forms of the thunderstorm and
+// earthquake examples.
+func TestConvert(t *testing.T) {
+	contents, err := ioutil.ReadFile(fixture("Oasis_ThunderstormWarning"))
+	if err != nil {
+		panic(err)
+	}
+	status, jsonForm := capctl("", "convert", fixture("Oasis_ThunderstormWarning"))
+	test(t, "JSON status", "0", fmt.Sprint(status))
+	status, xmlForm := capctl(jsonForm, "convert", "-to", "xml")
+	test(t, "XML status", "0", fmt.Sprint(status))
+	original, err := cap.ParseCAP(contents)
+	if err != nil {
+		panic(err)
+	}
+	converted, err := cap.ParseCAP([]byte(xmlForm))
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Round trip", original.ContentHash(), converted.ContentHash())
+
+	// the converted documents are checked against the schema types
+	for _, name := range []string{"Oasis_AmberAlert", "Oasis_EarthquakeReport", "Oasis_HomelandAlert", "Oasis_ThunderstormWarning", "PelmorexNAADS_WindWarning"} {
+		_, jsonForm := capctl("", "convert", fixture(name))
+		_, xmlForm := capctl(jsonForm, "convert", "-to", "xml")
+		status, out := capctl(xmlForm, "validate", "-")
+		test(t, "Converted "+name, "<stdin>: valid\n", out)
+		test(t, "Converted status "+name, "0", fmt.Sprint(status))
+	}
+
+	status, out := capctl("", "convert", "-to", "geojson", fixture("Oasis_ThunderstormWarning"))
+	test(t, "GeoJSON status", "0", fmt.Sprint(status))
+	var doc geoFeatureCollection
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatal(err)
+	}
+	test(t, "GeoJSON features", "1", fmt.Sprint(len(doc.Features)))
+	test(t, "GeoJSON geometry", "Polygon", doc.Features[0].Geometry.Type)
+	test(t, "GeoJSON coordinates", "[[[-120.14 38.47] [-119.95 38.34] [-119.74 38.52] [-119.89 38.62] [-120.14 38.47]]]", fmt.Sprint(doc.Features[0].Geometry.Coordinates))
+	test(t, "GeoJSON expires", "2003-06-17T16:00:00-07:00", doc.Features[0].Properties.Expires)
+
+	_, out = capctl("", "convert", "-to", "geojson", fixture("Oasis_EarthquakeReport"))
+	test(t, "GeoJSON point", "true", fmt.Sprint(strings.Contains(out, `"type": "Point"`)))
+
+	status, out = capctl("", "convert", "-to", "kml", fixture("Oasis_ThunderstormWarning"))
+	test(t, "KML status", "0", fmt.Sprint(status))
+	test(t, "KML name", "true", fmt.Sprint(strings.Contains(out, "<name>SEVERE THUNDERSTORM WARNING</name>")))
+	test(t, "KML coordinates", "true", fmt.Sprint(strings.Contains(out, "<coordinates>-120.14,38.47 -119.95,38.34 -119.74,38.52 -119.89,38.62 -120.14,38.47</coordinates>")))
+
+	status, _ = capctl("", "convert", "-to", "shapefile", fixture("Oasis_ThunderstormWarning"))
+	test(t, "Unknown format", "2", fmt.Sprint(status))
+}
+
+// TestCircleRing tests that the vertices approximating a circle lie on it.
+func TestCircleRing(t *testing.T) {
+	circle := cap.Circle{Center: cap.Point{Latitude: 46.2, Longitude: -74.5}, Radius: 10}
+	ring := circleRing(circle)
+	test(t, "Vertices", fmt.Sprint(circleSegments+1), fmt.Sprint(len(ring)))
+	test(t, "Closed", "true", fmt.Sprint(ring[0] == ring[len(ring)-1]))
+	for _, p := range ring {
+		if math.Abs(p.DistanceKm(circle.Center)-circle.Radius) > 0.001 {
+			t.Errorf("Vertex %s is %f km from the center", p, p.DistanceKm(circle.Center))
+		}
+	}
+}
+
+// TestInspect tests the summary of the thunderstorm example while it is in
+// effect.
+func TestInspect(t *testing.T) {
+	status, out := capctl("", "inspect", "-at", "2003-06-17T15:00:00-07:00", fixture("Oasis_ThunderstormWarning"))
+	test(t, "Status", "0", fmt.Sprint(status))
+	for _, line := range []string{
+		"  In effect   yes at 2003-06-17T15:00:00-07:00\n",
+		"  Info 1 (en-US): SEVERE THUNDERSTORM\n",
+		"    Window      2003-06-17T14:57:00-07:00 (sent) to 2003-06-17T16:00:00-07:00\n",
+		"    Phase       Active, in effect: yes\n",
+		"                  SAME = 006109\n",
+	} {
+		test(t, "Line "+strings.TrimSpace(line), "true", fmt.Sprint(strings.Contains(out, line)))
+	}
+
+	_, out = capctl("", "inspect", "-at", "2003-06-17T16:00:00-07:00", fixture("Oasis_ThunderstormWarning"))
+	test(t, "Expired", "true", fmt.Sprint(strings.Contains(out, "    Phase       Expired, in effect: no\n")))
+	status, _ = capctl("", "inspect", "-at", "tomorrow")
+	test(t, "Bad time", "2", fmt.Sprint(status))
+}
+
+// TestVerify tests the signatures of the NAADS example, trusting the
+// certificates of its signers.
+func TestVerify(t *testing.T) {
+	contents, err := ioutil.ReadFile(fixture("PelmorexNAADS_WindWarning"))
+	if err != nil {
+		panic(err)
+	}
+	alert, err := cap.ParseCAP(contents)
+	if err != nil {
+		panic(err)
+	}
+	var bundle bytes.Buffer
+	for _, sig := range alert.Signature {
+		der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(sig.X509Certificate), ""))
+		if err != nil {
+			panic(err)
+		}
+		pem.Encode(&bundle, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+	}
+	dir, err := ioutil.TempDir("", "capctl")
+	if err != nil {
+		panic(err)
+	}
+	defer os.RemoveAll(dir)
+	ca := filepath.Join(dir, "bundle.pem")
+	if err := ioutil.WriteFile(ca, bundle.Bytes(), 0600); err != nil {
+		panic(err)
+	}
+
+	status, out := capctl("", "verify", "-ca", ca, "-at", "2018-06-01T00:00:00-00:00", fixture("PelmorexNAADS_WindWarning"))
+	test(t, "Status", "0", fmt.Sprint(status))
+	expected := fixture("PelmorexNAADS_WindWarning") + ": 2 signature(s)\n" +
+		"  \"NAADS Signature\" signed by dss1.pelmorex.com: valid\n" +
+		"  \"Environment Canada\" signed by meteo.gc.ca: valid\n"
+	test(t, "Output", expected, out)
+
+	status, out = capctl("", "verify", "-ca", ca, "-at", "sent", fixture("PelmorexNAADS_WindWarning"))
+	test(t, "Expired status", "1", fmt.Sprint(status))
+	test(t, "Expired", "true", fmt.Sprint(strings.Contains(out, "meteo.gc.ca: x509: certificate has expired")))
+
+	status, out = capctl("", "verify", "-ca", ca, fixture("Oasis_AmberAlert"))
+	test(t, "Unsigned status", "1", fmt.Sprint(status))
+	test(t, "Unsigned", fixture("Oasis_AmberAlert")+": Error: alert is not signed\n", out)
+
+	status, _ = capctl("", "verify", fixture("PelmorexNAADS_WindWarning"))
+	test(t, "Missing bundle", "2", fmt.Sprint(status))
+}
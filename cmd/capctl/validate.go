@@ -0,0 +1,341 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/thetannerryan/cap"
+)
+
+// profile checks the rules of a CAP profile that go beyond CAP 1.2.
+type profile func(alert *cap.Alert) []*cap.ValidationError
+
+// profiles maps each profile name to its checks.
+var profiles = map[string]profile{
+	"cap-cp": capCP,
+	"ipaws":  ipaws,
+}
+
+// problem is an error or warning within a report.
+type problem struct {
+	Profile string `json:"profile,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// String returns the problem as a single line.
+func (p problem) String() string {
+	msg := p.Message
+	if p.Path != "" {
+		msg += " at " + p.Path
+	}
+	if p.Line > 0 {
+		msg += " (line " + strconv.Itoa(p.Line) + ")"
+	}
+	if p.Profile != "" {
+		msg = p.Profile + ": " + msg
+	}
+	return msg
+}
+
+// report is the outcome of validating one alert.
+type report struct {
+	File       string    `json:"file"`
+	Identifier string    `json:"identifier,omitempty"`
+	Valid      bool      `json:"valid"`
+	Errors     []problem `json:"errors,omitempty"`
+	Warnings   []problem `json:"warnings,omitempty"`
+}
+
+// validate implements the validate command.
+func validate(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := newFlags("validate", "[-profile cap-cp,ipaws] [-json] [-lenient] [file ...]", stderr)
+	profileList := flags.String("profile", "", "comma separated `profiles` to check: cap-cp, ipaws")
+	asJSON := flags.Bool("json", false, "print the reports as JSON")
+	lenient := flags.Bool("lenient", false, "accept unknown codes and common dateTime variants, with warnings")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	names := splitList(*profileList)
+	for _, name := range names {
+		if _, ok := profiles[name]; !ok {
+			fmt.Fprintf(stderr, "capctl: unknown profile %q\n", name)
+			return exitUsage
+		}
+	}
+	inputs, err := readInputs(flags.Args(), stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, "capctl:", err)
+		return exitFail
+	}
+
+	opts := cap.ParseOptions{Lenient: *lenient, TolerantDateTime: *lenient}
+	reports := make([]report, len(inputs))
+	status := exitOK
+	for i, in := range inputs {
+		reports[i] = validateInput(in, opts, names)
+		if !reports[i].Valid {
+			status = exitFail
+		}
+	}
+
+	if *asJSON {
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			fmt.Fprintln(stderr, "capctl:", err)
+			return exitFail
+		}
+		fmt.Fprintf(stdout, "%s\n", data)
+		return status
+	}
+	for _, r := range reports {
+		if r.Valid {
+			fmt.Fprintf(stdout, "%s: valid\n", r.File)
+		} else {
+			fmt.Fprintf(stdout, "%s: not valid\n", r.File)
+		}
+		for _, p := range r.Errors {
+			fmt.Fprintf(stdout, "  error: %s\n", p)
+		}
+		for _, p := range r.Warnings {
+			fmt.Fprintf(stdout, "  warning: %s\n", p)
+		}
+	}
+	return status
+}
+
+// validateInput parses and validates one alert against CAP 1.2 and the named
+// profiles.
+func validateInput(in input, opts cap.ParseOptions, names []string) report {
+	r := report{File: in.name}
+	alert, warnings, err := parseAlert(in.data, opts)
+	for _, w := range warnings {
+		r.Warnings = append(r.Warnings, problem{Path: w.Path, Line: w.Line, Message: w.Message + " " + strconv.Quote(w.Value)})
+	}
+	if err != nil {
+		var parseErr *cap.ParseError
+		if errors.As(err, &parseErr) {
+			r.Errors = append(r.Errors, problem{
+				Path:    parseErr.Path,
+				Line:    parseErr.Line,
+				Column:  parseErr.Column,
+				Message: parseErr.Err.Error(),
+			})
+		} else {
+			r.Errors = append(r.Errors, problem{Message: err.Error()})
+		}
+		return r
+	}
+	r.Identifier = alert.Identifier
+
+	var errs cap.ValidationErrors
+	if !errors.As(alert.Validate(), &errs) {
+		errs = nil
+	}
+	for _, e := range errs {
+		r.Errors = append(r.Errors, problem{Path: e.Path, Message: "Error: " + e.Msg})
+	}
+	if !isJSON(in.data) {
+		for _, e := range schema(in.data) {
+			r.Errors = append(r.Errors, problem{Path: e.Path, Message: "Error: " + e.Msg})
+		}
+	}
+	for _, name := range names {
+		for _, e := range profiles[name](alert) {
+			r.Errors = append(r.Errors, problem{Profile: name, Path: e.Path, Message: "Error: " + e.Msg})
+		}
+	}
+	r.Valid = len(r.Errors) == 0
+	return r
+}
+
+// repeated are the CAP 1.2 elements that may occur more than once, and are
+// given an index within paths.
+var repeated = map[string]bool{
+	"code": true, "info": true, "category": true, "responseType": true,
+	"eventCode": true, "parameter": true, "resource": true, "area": true,
+	"polygon": true, "circle": true, "geocode": true,
+}
+
+// language matches the xs:language type.
+var language = regexp.MustCompile(`^[a-zA-Z]{1,8}(-[a-zA-Z0-9]{1,8})*$`)
+
+const (
+	// capNamespace is the namespace of CAP 1.2 documents.
+	capNamespace = "urn:oasis:names:tc:emergency:cap:1.2"
+	// zeroTime is the prefix of a dateTime written for an unset time.
+	zeroTime = "0001-01-01T00:00:00"
+)
+
+// schema checks the values of the CAP 1.2 schema types that parsing accepts:
+// the language of each info block, and polygons and dateTimes, which must
+// not be written empty or unset.
+func schema(data []byte) []*cap.ValidationError {
+	var errs []*cap.ValidationError
+	fail := func(path, val, name string) {
+		errs = append(errs, &cap.ValidationError{Path: path, Msg: "illegal value " + strconv.Quote(val) + " for " + name})
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var path []string
+	var counts []map[string]int
+	var text string
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			// the document has been parsed, so this is its end
+			return errs
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if token.Name.Space != capNamespace {
+				decoder.Skip()
+				continue
+			}
+			name := token.Name.Local
+			if len(counts) > 0 && repeated[name] {
+				counts[len(counts)-1][name]++
+				name += "[" + strconv.Itoa(counts[len(counts)-1][name]) + "]"
+			}
+			path = append(path, name)
+			counts = append(counts, make(map[string]int))
+			text = ""
+		case xml.CharData:
+			text += string(token)
+		case xml.EndElement:
+			val := strings.TrimSpace(text)
+			switch name := token.Name.Local; name {
+			case "language":
+				if !language.MatchString(val) {
+					fail(strings.Join(path, "/"), val, name)
+				}
+			case "polygon":
+				if val == "" {
+					fail(strings.Join(path, "/"), val, name)
+				}
+			case "sent", "effective", "onset", "expires":
+				if strings.HasPrefix(val, zeroTime) {
+					fail(strings.Join(path, "/"), val, name)
+				}
+			}
+			path, counts = path[:len(path)-1], counts[:len(counts)-1]
+			text = ""
+		}
+	}
+}
+
+// index returns the path of the nth (from 0) repeated element.
+func index(path, name string, n int) string {
+	return path + "/" + name + "[" + strconv.Itoa(n+1) + "]"
+}
+
+// hasValue reports whether a key-value pair has the name, or a name with the
+// prefix if it ends in a colon, and a value matching the pattern.
+func hasValue(pairs []cap.KeyValue, name string, pattern *regexp.Regexp) bool {
+	for _, pair := range pairs {
+		matched := pair.ValueName == name
+		if strings.HasSuffix(name, ":") {
+			matched = strings.HasPrefix(pair.ValueName, name)
+		}
+		if matched && (pattern == nil || pattern.MatchString(strings.TrimSpace(pair.Value))) {
+			return true
+		}
+	}
+	return false
+}
+
+// needsInfo reports whether an alert of the message type must carry at least
+// one info block under a profile.
+func needsInfo(msgType cap.MsgType) bool {
+	return msgType == cap.MsgTypeAlert || msgType == cap.MsgTypeUpdate
+}
+
+// capCP checks the main rules of the Canadian Profile (CAP-CP): each info
+// block is in English or French and carries a CAP-CP event code, and each
+// area carries a CAP-CP location geocode.
+func capCP(alert *cap.Alert) []*cap.ValidationError {
+	var errs []*cap.ValidationError
+	fail := func(path, msg string) {
+		errs = append(errs, &cap.ValidationError{Path: path, Msg: msg})
+	}
+	if needsInfo(alert.MsgType) && len(alert.Info) == 0 {
+		fail("alert/info", "missing required element")
+	}
+	for i := range alert.Info {
+		info := &alert.Info[i]
+		path := index("alert", "info", i)
+		if info.Language != "en-CA" && info.Language != "fr-CA" {
+			fail(path+"/language", "language must be en-CA or fr-CA")
+		}
+		if !hasValue(info.EventCode, "profile:CAP-CP:Event:", nil) {
+			fail(path+"/eventCode", "missing CAP-CP event code")
+		}
+		if len(info.Area) == 0 {
+			fail(path+"/area", "missing required element")
+		}
+		for j := range info.Area {
+			if !hasValue(info.Area[j].Geocode, "profile:CAP-CP:Location:", nil) {
+				fail(index(path, "area", j)+"/geocode", "missing CAP-CP location geocode")
+			}
+		}
+	}
+	return errs
+}
+
+var (
+	// sameEvent matches a three letter SAME event code.
+	sameEvent = regexp.MustCompile(`^[A-Z]{3}$`)
+	// sameLocation matches a six digit SAME location code.
+	sameLocation = regexp.MustCompile(`^\d{6}$`)
+)
+
+// ipaws checks the main rules of the IPAWS v1.0 profile: the alert carries
+// the IPAWSv1.0 code, and each info block has an expiry time and a SAME event
+// code, and each area a SAME location geocode.
+func ipaws(alert *cap.Alert) []*cap.ValidationError {
+	var errs []*cap.ValidationError
+	fail := func(path, msg string) {
+		errs = append(errs, &cap.ValidationError{Path: path, Msg: msg})
+	}
+	found := false
+	for _, code := range alert.Code {
+		found = found || strings.TrimSpace(code) == "IPAWSv1.0"
+	}
+	if !found {
+		fail("alert/code", "missing IPAWSv1.0 code")
+	}
+	if needsInfo(alert.MsgType) && len(alert.Info) == 0 {
+		fail("alert/info", "missing required element")
+	}
+	for i := range alert.Info {
+		info := &alert.Info[i]
+		path := index("alert", "info", i)
+		if !hasValue(info.EventCode, "SAME", sameEvent) {
+			fail(path+"/eventCode", "missing SAME event code")
+		}
+		if info.Expires.Time().IsZero() {
+			fail(path+"/expires", "missing required element")
+		}
+		if len(info.Area) == 0 {
+			fail(path+"/area", "missing required element")
+		}
+		for j := range info.Area {
+			if !hasValue(info.Area[j].Geocode, "SAME", sameLocation) {
+				fail(index(path, "area", j)+"/geocode", "missing SAME location geocode")
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/thetannerryan/cap"
+	"github.com/thetannerryan/cap/dsig"
+)
+
+// verify implements the verify command.
+func verify(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := newFlags("verify", "-ca bundle.pem [-at time|sent] [file ...]", stderr)
+	bundle := flags.String("ca", "", "PEM `file` of the trusted certificate authorities (required)")
+	at := flags.String("at", "", "check the certificates at this `time`, or at the sent time of each alert if \"sent\", rather than now")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *bundle == "" {
+		flags.Usage()
+		return exitUsage
+	}
+	pem, err := ioutil.ReadFile(*bundle)
+	if err != nil {
+		fmt.Fprintln(stderr, "capctl:", err)
+		return exitUsage
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pem) {
+		fmt.Fprintf(stderr, "capctl: no certificates found in %s\n", *bundle)
+		return exitUsage
+	}
+	opts := x509.VerifyOptions{Roots: roots}
+	if *at != "sent" {
+		if opts.CurrentTime, err = parseTime(*at); err != nil {
+			fmt.Fprintln(stderr, "capctl:", err)
+			return exitUsage
+		}
+	}
+	inputs, err := readInputs(flags.Args(), stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, "capctl:", err)
+		return exitFail
+	}
+
+	status := exitOK
+	for _, in := range inputs {
+		if !verifyInput(stdout, in, opts, *at == "sent") {
+			status = exitFail
+		}
+	}
+	return status
+}
+
+// verifyInput checks and reports the signatures of one alert, and reports
+// whether all are valid. If atSent is true, the certificates are checked at
+// the sent time of the alert.
+func verifyInput(w io.Writer, in input, opts x509.VerifyOptions, atSent bool) bool {
+	if isJSON(in.data) {
+		fmt.Fprintf(w, "%s: Error: signatures may only be verified in the XML form\n", in.name)
+		return false
+	}
+	alert, err := cap.ParseCAP(in.data)
+	if err == nil && atSent {
+		opts.CurrentTime = alert.Sent.Time()
+	}
+	var results []dsig.Result
+	if err == nil {
+		results, err = dsig.Check(in.data, opts)
+	}
+	if err != nil {
+		fmt.Fprintf(w, "%s: %s\n", in.name, err)
+		return false
+	}
+	fmt.Fprintf(w, "%s: %d signature(s)\n", in.name, len(results))
+	valid := true
+	for _, result := range results {
+		signer := "unknown signer"
+		if result.Certificate != nil {
+			signer = "signed by " + result.Certificate.Subject.CommonName
+		}
+		outcome := "valid"
+		if result.Err != nil {
+			outcome = result.Err.Error()
+			valid = false
+		}
+		fmt.Fprintf(w, "  %s %s: %s\n", strconv.Quote(result.ID), signer, outcome)
+	}
+	return valid
+}
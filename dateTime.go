@@ -7,35 +7,117 @@ package cap
 import (
 	"encoding/json"
 	"encoding/xml"
+	"regexp"
 	"strings"
 	"time"
 )
 
 // DateTime is to represent a time field in an Alert
 type DateTime struct {
-	val time.Time
+	val  time.Time
+	plus bool // a zero offset was written as +00:00 rather than -00:00
+	frac int  // digits of fractional seconds
 }
 
 // XML dateTime format (as implemented in CAP 1.2)
 var timeFormat = "2006-01-02T15:04:05-07:00"
 
-// String returns the XML dateTime representation of the DateTime
+// strictTime matches the CAP 1.2 dateTime format, which requires an explicit
+// offset and forbids alphabetic timezone designators such as Z.
+var strictTime = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d{1,9})?[+-]\d{2}:\d{2}$`)
+
+// tolerantTime matches common variants of the CAP 1.2 dateTime format: a
+// lowercase or space date separator, a missing seconds field, and an offset
+// that is missing, written as Z, or written without a colon.
+var tolerantTime = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})[Tt ](\d{2}:\d{2})(:\d{2})?(\.\d{1,9})?([Zz]|[+-]\d{2}(?::?\d{2})?)?$`)
+
+// String returns the XML dateTime representation of the DateTime. A zero
+// offset is written as -00:00, unless it was originally parsed as +00:00.
 func (t DateTime) String() string {
-	obj := t.val.Format(timeFormat)
+	layout := timeFormat
+	if t.frac > 0 {
+		layout = "2006-01-02T15:04:05." + strings.Repeat("0", t.frac) + "-07:00"
+	}
+	obj := t.val.Format(layout)
+	if t.plus {
+		return obj
+	}
 	return strings.Replace(obj, "+00:00", "-00:00", 1)
 }
 
+// NewDateTime returns a DateTime for the time, such as for the builder. Its
+// offset is kept, and a zero offset is written as -00:00. Fractional seconds
+// are not written.
+func NewDateTime(t time.Time) DateTime {
+	return DateTime{val: t.Truncate(time.Second)}
+}
+
+// ParseDateTime returns the DateTime of a CAP 1.2 dateTime string, keeping its
+// fractional seconds and the sign of a zero offset.
+func ParseDateTime(val string) (DateTime, error) {
+	var t DateTime
+	err := parseTime(&t, val)
+	return t, err
+}
+
+// badTime returns an error for text that is not a valid dateTime.
+func badTime(val string) error {
+	return &valueError{
+		msg:    "Error: illegal value " + val + " for DateTime",
+		value:  val,
+		kind:   ErrBadDateTime,
+		offset: -1,
+	}
+}
+
 // parseTime will initialize a dateTime struct given a XML dateTime string. If
-// the string is not formatted correctly, an error will be returned.
+// the string is not formatted as required by CAP 1.2, an error will be
+// returned.
 func parseTime(t *DateTime, val string) error {
+	if !strictTime.MatchString(val) {
+		return badTime(val)
+	}
 	timeObj, err := time.Parse(timeFormat, val)
 	if err != nil {
-		return err
+		return badTime(val)
 	}
 	t.val = timeObj
+	t.plus = strings.HasSuffix(val, "+00:00")
+	t.frac = 0
+	if i := strings.IndexByte(val, '.'); i >= 0 {
+		t.frac = len(val) - i - len(".-07:00")
+	}
 	return nil
 }
 
+// parseTolerantTime will initialize a dateTime struct given a XML dateTime
+// string in one of the variants matched by tolerantTime. A missing offset is
+// taken to be UTC. It returns true if the string was not formatted as required
+// by CAP 1.2.
+func parseTolerantTime(t *DateTime, val string) (bool, error) {
+	match := tolerantTime.FindStringSubmatch(strings.TrimSpace(val))
+	if match == nil {
+		return false, badTime(val)
+	}
+	date, clock, seconds, frac, zone := match[1], match[2], match[3], match[4], match[5]
+	if seconds == "" {
+		seconds = ":00"
+	}
+	switch len(zone) {
+	case 0, 1:
+		zone = "-00:00"
+	case 3:
+		zone += ":00"
+	case 5:
+		zone = zone[:3] + ":" + zone[3:]
+	}
+	normal := date + "T" + clock + seconds + frac + zone
+	if err := parseTime(t, normal); err != nil {
+		return false, badTime(val)
+	}
+	return normal != val, nil
+}
+
 // Time returns a standard time struct for the DateTime
 func (t *DateTime) Time() time.Time {
 	return t.val
@@ -44,15 +126,31 @@ func (t *DateTime) Time() time.Time {
 // UnmarshalXML will be used during the XML unmarshaling for conversion to
 // DateTime.
 func (t *DateTime) UnmarshalXML(decoder *xml.Decoder, elem xml.StartElement) error {
+	offset := decoder.InputOffset()
 	var val string
 	if err := decoder.DecodeElement(&val, &elem); err != nil {
 		return err
 	}
-	return parseTime(t, val)
+	state := stateOf(decoder)
+	if state == nil || !state.opts.TolerantDateTime {
+		return atOffset(parseTime(t, val), offset)
+	}
+	changed, err := parseTolerantTime(t, val)
+	if err != nil {
+		return atOffset(err, offset)
+	}
+	if changed {
+		state.warn(elem, offset, val, "accepted non-conforming dateTime")
+	}
+	return nil
 }
 
-// MarshalXML converts the DateTime back to a string when marshaling XML.
+// MarshalXML converts the DateTime back to a string when marshaling XML. A
+// zero DateTime is unset, and no element is written.
 func (t DateTime) MarshalXML(encoder *xml.Encoder, elem xml.StartElement) error {
+	if t.val.IsZero() {
+		return nil
+	}
 	return encoder.EncodeElement(t.String(), elem)
 }
 
@@ -0,0 +1,77 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/thetannerryan/cap"
+)
+
+// TestDateTimeStrict tests that conforming dateTime values round trip exactly,
+// and that variants forbidden by CAP 1.2 are rejected.
+func TestDateTimeStrict(t *testing.T) {
+	for _, val := range []string{
+		"2003-06-17T14:57:00-07:00",
+		"2019-01-09T02:17:03-00:00",
+		"2019-01-09T02:17:03+00:00",
+		"2019-01-09T02:17:03.50-00:00",
+	} {
+		var dt cap.DateTime
+		if err := json.Unmarshal([]byte(`"`+val+`"`), &dt); err != nil {
+			t.Fatal(err)
+		}
+		test(t, "Strict round trip "+val, val, dt.String())
+	}
+
+	for _, val := range []string{
+		"2019-01-09T02:17:03Z",
+		"2019-01-09T02:17:03-0700",
+		"2019-01-09T02:17-07:00",
+		"2019-01-09T02:17:03",
+		"2019-13-09T02:17:03-00:00",
+	} {
+		var dt cap.DateTime
+		err := json.Unmarshal([]byte(`"`+val+`"`), &dt)
+		test(t, "Strict rejects "+val, "true", fmt.Sprint(errors.Is(err, cap.ErrBadDateTime)))
+	}
+}
+
+// TestDateTimeTolerant tests that tolerant parsing normalizes common variants
+// and reports each as a Warning.
+func TestDateTimeTolerant(t *testing.T) {
+	alert := []byte(`<alert xmlns="urn:oasis:names:tc:emergency:cap:1.2">
+	<identifier>tolerant</identifier>
+	<sent>2019-01-09T02:17:03Z</sent>
+	<info>
+		<effective>2019-01-09T02:17:03.125-0700</effective>
+		<onset>2019-01-09t02:17+05</onset>
+		<expires>2019-01-10T02:17:03+00:00</expires>
+	</info>
+</alert>`)
+
+	_, err := cap.ParseCAP(alert)
+	test(t, "Strict error", "Error: illegal value 2019-01-09T02:17:03Z for DateTime at alert/sent (line 3, column 2)", fmt.Sprint(err))
+
+	parsed, warnings, err := cap.ParseCAPWithOptions(alert, cap.ParseOptions{TolerantDateTime: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := parsed.Info[0]
+	test(t, "Tolerant sent", "2019-01-09T02:17:03-00:00", parsed.Sent.String())
+	test(t, "Tolerant effective", "2019-01-09T02:17:03.125-07:00", info.Effective.String())
+	test(t, "Tolerant onset", "2019-01-09T02:17:00+05:00", info.Onset.String())
+	test(t, "Tolerant expires", "2019-01-10T02:17:03+00:00", info.Expires.String())
+
+	var messages []string
+	for _, warning := range warnings {
+		messages = append(messages, warning.String())
+	}
+	test(t, "Tolerant warnings", `alert/sent: accepted non-conforming dateTime "2019-01-09T02:17:03Z"; alert/info[1]/effective: accepted non-conforming dateTime "2019-01-09T02:17:03.125-0700"; alert/info[1]/onset: accepted non-conforming dateTime "2019-01-09t02:17+05"`, strings.Join(messages, "; "))
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Key identifies an Alert by its sender, identifier and sent time, which CAP
+// 1.2 requires to be unique. The sent time is normalized to UTC with the
+// fewest fractional digits, so that a copy re-serialized with another offset
+// or precision has the same Key. Keys may be compared with == and used as map
+// keys.
+type Key struct {
+	Sender     string
+	Identifier string
+	Sent       string
+}
+
+// Key returns the Key of the Alert.
+func (t *Alert) Key() Key {
+	sent := ""
+	if !t.Sent.val.IsZero() {
+		// the shortest fractional seconds of the instant, if any
+		frac := len(strings.TrimRight(strconv.Itoa(1e9 + t.Sent.val.Nanosecond())[1:], "0"))
+		sent = DateTime{val: t.Sent.val.UTC(), frac: frac}.String()
+	}
+	return Key{
+		Sender:     strings.TrimSpace(t.Sender),
+		Identifier: strings.TrimSpace(t.Identifier),
+		Sent:       sent,
+	}
+}
+
+// String returns the "sender,identifier,sent" form of the Key, as used within
+// the references element.
+func (k Key) String() string {
+	return k.Sender + "," + k.Identifier + "," + k.Sent
+}
+
+// canon builds the canonical form of an Alert, one element per line.
+type canon []string
+
+// text adds an element with whitespace collapsed. Empty elements are omitted.
+func (c *canon) text(name, val string) {
+	if val = strings.Join(strings.Fields(val), " "); val != "" {
+		*c = append(*c, name+"="+strconv.Quote(val))
+	}
+}
+
+// time adds a dateTime element as its UTC instant.
+func (c *canon) time(name string, val DateTime) {
+	if !val.val.IsZero() {
+		c.text(name, val.val.UTC().Format(time.RFC3339Nano))
+	}
+}
+
+// set adds a repeated element, sorted so that its order is ignored.
+func (c *canon) set(name string, vals []string) {
+	sorted := make([]string, 0, len(vals))
+	for _, val := range vals {
+		if val = strings.Join(strings.Fields(val), " "); val != "" {
+			sorted = append(sorted, val)
+		}
+	}
+	sort.Strings(sorted)
+	for _, val := range sorted {
+		c.text(name, val)
+	}
+}
+
+// keyValues adds repeated key-value pairs, such as parameters.
+func (c *canon) keyValues(name string, pairs []KeyValue) {
+	vals := make([]string, len(pairs))
+	for i, pair := range pairs {
+		vals[i] = strings.TrimSpace(pair.ValueName) + "=" + strings.TrimSpace(pair.Value)
+	}
+	c.set(name, vals)
+}
+
+// block adds a nested element, such as an info block.
+func (c *canon) block(name string, blocks []canon) {
+	vals := make([]string, len(blocks))
+	for i, block := range blocks {
+		vals[i] = strings.Join(block, "\n")
+	}
+	sort.Strings(vals)
+	for _, val := range vals {
+		*c = append(*c, name+"="+strconv.Quote(val))
+	}
+}
+
+// ContentHash returns the hex SHA-256 digest of the canonical form of the
+// Alert. Copies of an Alert that differ only in whitespace, the order of
+// repeated elements, the offsets of dateTime values, or the formatting of
+// coordinates have the same hash. Signatures are not included, so an Alert
+// re-signed by a distributor keeps its hash.
+func (t *Alert) ContentHash() string {
+	var c canon
+	c.text("identifier", t.Identifier)
+	c.text("sender", t.Sender)
+	c.time("sent", t.Sent)
+	c.text("status", t.CodeText(t.Status))
+	c.text("msgType", t.CodeText(t.MsgType))
+	c.text("source", t.Source)
+	c.text("scope", t.CodeText(t.Scope))
+	c.text("restriction", t.Restriction)
+	c.set("addresses", t.Addresses.Values())
+	c.set("code", t.Code)
+	c.text("note", t.Note)
+	c.set("references", t.References.Values())
+	c.set("incidents", t.Incidents.Values())
+	infos := make([]canon, len(t.Info))
+	for i := range t.Info {
+		infos[i] = t.Info[i].canon()
+	}
+	c.block("info", infos)
+
+	sum := sha256.Sum256([]byte(strings.Join(c, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// canon returns the canonical form of the Info.
+func (t *Info) canon() canon {
+	var c canon
+	c.text("language", infoLanguage(t))
+	c.set("category", codeTexts(len(t.Category), func(i int) string { return t.CodeText(t.Category[i]) }))
+	c.text("event", t.Event)
+	c.set("responseType", codeTexts(len(t.ResponseType), func(i int) string { return t.CodeText(t.ResponseType[i]) }))
+	c.text("urgency", t.CodeText(t.Urgency))
+	c.text("severity", t.CodeText(t.Severity))
+	c.text("certainty", t.CodeText(t.Certainty))
+	c.text("audience", t.Audience)
+	c.keyValues("eventCode", t.EventCode)
+	c.time("effective", t.Effective)
+	c.time("onset", t.Onset)
+	c.time("expires", t.Expires)
+	c.text("senderName", t.SenderName)
+	c.text("headline", t.Headline)
+	c.text("description", t.Description)
+	c.text("instruction", t.Instruction)
+	c.text("web", t.Web)
+	c.text("contact", t.Contact)
+	c.keyValues("parameter", t.Parameter)
+	resources := make([]canon, len(t.Resource))
+	for i := range t.Resource {
+		res := &t.Resource[i]
+		c := &resources[i]
+		c.text("resourceDesc", res.ResourceDesc)
+		c.text("mimeType", res.MimeType)
+		c.text("size", sizeText(res.Size))
+		c.text("uri", res.URI)
+		c.text("derefUri", res.DerefURI)
+		c.text("digest", res.Digest)
+	}
+	c.block("resource", resources)
+	areas := make([]canon, len(t.Area))
+	for i := range t.Area {
+		areas[i] = t.Area[i].canon()
+	}
+	c.block("area", areas)
+	return c
+}
+
+// canon returns the canonical form of the Area. Coordinates are rewritten in
+// their shortest form, unless they are malformed.
+func (a *Area) canon() canon {
+	var c canon
+	c.text("areaDesc", a.AreaDesc)
+	if points, err := a.PolygonPoints(); err == nil {
+		vals := make([]string, len(points))
+		for i, point := range points {
+			vals[i] = point.String()
+		}
+		c.text("polygon", strings.Join(vals, " "))
+	} else {
+		c.text("polygon", a.Polygon.String())
+	}
+	circles := make([]string, len(a.Circle))
+	for i, val := range a.Circle {
+		circles[i] = val
+		if circle, err := parseCircle(val); err == nil {
+			circles[i] = circle.String()
+		}
+	}
+	c.set("circle", circles)
+	c.keyValues("geocode", a.Geocode)
+	c.text("altitude", floatText(a.Altitude))
+	c.text("ceiling", floatText(a.Ceiling))
+	return c
+}
+
+//go:generate stringer -type=Seen -trimprefix=Seen
+
+// Seen is the outcome of offering an Alert to a Deduper.
+type Seen int
+
+const (
+	// SeenNew :: No Alert with the same Key has been seen
+	SeenNew Seen = 0
+	// SeenDuplicate :: An Alert with the same Key and content has been seen
+	SeenDuplicate Seen = 1
+	// SeenConflict :: An Alert with the same Key but different content has
+	// been seen
+	SeenConflict Seen = 2
+)
+
+// dedupEntry is an Alert remembered by a Deduper.
+type dedupEntry struct {
+	key     Key
+	hash    string
+	expires time.Time
+}
+
+// Deduper remembers the Keys and content hashes of recently seen Alerts, to
+// drop the copies of an Alert received from several sources. Each Alert is
+// remembered for a fixed time after it was first seen, and the oldest Alerts
+// are forgotten once the limit is reached. A Deduper is safe for concurrent
+// use.
+type Deduper struct {
+	ttl   time.Duration
+	limit int
+	clock Clock
+
+	mu      sync.Mutex
+	order   *list.List // of *dedupEntry, oldest first
+	entries map[Key]*list.Element
+}
+
+// NewDeduper returns a Deduper remembering each Alert for the ttl, and at most
+// limit Alerts at once. If the limit is not positive, the number of Alerts is
+// unbounded. If the clock is nil, the SystemClock is used.
+func NewDeduper(ttl time.Duration, limit int, clock Clock) *Deduper {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &Deduper{
+		ttl:     ttl,
+		limit:   limit,
+		clock:   clock,
+		order:   list.New(),
+		entries: make(map[Key]*list.Element),
+	}
+}
+
+// Len returns the number of Alerts remembered.
+func (d *Deduper) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.expire(d.clock.Now())
+	return len(d.entries)
+}
+
+// Check reports whether an Alert with the same Key has been seen, and if so
+// whether its content was the same. A new Alert is remembered. The content of
+// the first Alert seen under a Key is kept, so that every later copy differing
+// from it is reported as a conflict.
+func (d *Deduper) Check(alert *Alert) Seen {
+	key, hash := alert.Key(), alert.ContentHash()
+	now := d.clock.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.expire(now)
+	if elem, ok := d.entries[key]; ok {
+		if elem.Value.(*dedupEntry).hash == hash {
+			return SeenDuplicate
+		}
+		return SeenConflict
+	}
+	d.entries[key] = d.order.PushBack(&dedupEntry{key: key, hash: hash, expires: now.Add(d.ttl)})
+	if d.limit > 0 && len(d.entries) > d.limit {
+		d.evict(d.order.Front())
+	}
+	return SeenNew
+}
+
+// expire forgets the Alerts whose time has passed. As every Alert is
+// remembered for the same time, they expire in the order they were seen.
+func (d *Deduper) expire(now time.Time) {
+	for elem := d.order.Front(); elem != nil; elem = d.order.Front() {
+		if now.Before(elem.Value.(*dedupEntry).expires) {
+			return
+		}
+		d.evict(elem)
+	}
+}
+
+// evict forgets the Alert of the list element.
+func (d *Deduper) evict(elem *list.Element) {
+	delete(d.entries, elem.Value.(*dedupEntry).key)
+	d.order.Remove(elem)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/thetannerryan/cap"
+)
+
+// reserialized returns the thunderstorm example as another distributor might
+// send it: with other whitespace, element order, offsets and coordinates.
+func reserialized() *cap.Alert {
+	alert := parseThunderstorm()
+	sent, err := cap.ParseDateTime("2003-06-17T21:57:00+00:00")
+	if err != nil {
+		panic(err)
+	}
+	alert.Sent = sent
+	alert.Identifier = " " + alert.Identifier + "\n"
+	info := &alert.Info[0]
+	info.Description = "\n      " + info.Description + "\n    "
+	area := &info.Area[0]
+	area.Geocode[0], area.Geocode[2] = area.Geocode[2], area.Geocode[0]
+	area.Polygon = cap.NewList("38.470,-120.14", "38.34,-119.950", "38.52,-119.74", "38.62,-119.89", "38.47,-120.140")
+	return alert
+}
+
+// TestKey tests the Key and content hash of copies of the thunderstorm
+// example.
+func TestKey(t *testing.T) {
+	alert, other := parseThunderstorm(), reserialized()
+	test(t, "Key", "KSTO@NWS.NOAA.GOV,KSTO1055887203,2003-06-17T21:57:00-00:00", alert.Key().String())
+	test(t, "Same key", "true", fmt.Sprint(alert.Key() == other.Key()))
+	test(t, "Same hash", alert.ContentHash(), other.ContentHash())
+
+	precise, err := cap.ParseDateTime("2003-06-17T14:57:00.50-07:00")
+	if err != nil {
+		panic(err)
+	}
+	other.Sent = precise
+	test(t, "Fractional key", "KSTO@NWS.NOAA.GOV,KSTO1055887203,2003-06-17T21:57:00.5-00:00", other.Key().String())
+	alert.Sent, _ = cap.ParseDateTime("2003-06-17T21:57:00.500-00:00")
+	test(t, "Same fractional key", "true", fmt.Sprint(alert.Key() == other.Key()))
+	alert, other = parseThunderstorm(), reserialized()
+	test(t, "Hash length", "64", fmt.Sprint(len(alert.ContentHash())))
+
+	other.Info[0].Instruction = "TAKE COVER NOW."
+	test(t, "Changed hash", "false", fmt.Sprint(alert.ContentHash() == other.ContentHash()))
+	other = reserialized()
+	other.Info[0].Area[0].Geocode = other.Info[0].Area[0].Geocode[1:]
+	test(t, "Removed geocode", "false", fmt.Sprint(alert.ContentHash() == other.ContentHash()))
+}
+
+// TestDeduper tests the duplicates, conflicts, expiry and limit of a Deduper.
+func TestDeduper(t *testing.T) {
+	clock := &stepClock{now: time.Date(2003, 6, 17, 22, 0, 0, 0, time.UTC)}
+	deduper := cap.NewDeduper(time.Hour, 2, clock)
+	alert := parseThunderstorm()
+	test(t, "New", "New", deduper.Check(alert).String())
+	test(t, "Duplicate", "Duplicate", deduper.Check(reserialized()).String())
+	changed := reserialized()
+	changed.Info[0].Severity = cap.SeverityExtreme
+	test(t, "Conflict", "Conflict", deduper.Check(changed).String())
+	test(t, "Kept first", "Duplicate", deduper.Check(alert).String())
+
+	clock.now = clock.now.Add(time.Hour)
+	test(t, "Expired", "0", fmt.Sprint(deduper.Len()))
+	test(t, "New again", "New", deduper.Check(alert).String())
+
+	for _, identifier := range []string{"second", "third"} {
+		other := parseThunderstorm()
+		other.Identifier = identifier
+		test(t, "New "+identifier, "New", deduper.Check(other).String())
+	}
+	test(t, "Limit", "2", fmt.Sprint(deduper.Len()))
+	test(t, "Evicted", "New", deduper.Check(alert).String())
+}
@@ -0,0 +1,373 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+//go:generate stringer -type=ChangeKind -trimprefix=Change
+
+// ChangeKind is the nature of a Change between two versions of an Alert.
+type ChangeKind int
+
+const (
+	// ChangeAdded :: The element is present only in the new version
+	ChangeAdded ChangeKind = 0
+	// ChangeRemoved :: The element is present only in the old version
+	ChangeRemoved ChangeKind = 1
+	// ChangeModified :: The element is present in both versions, with
+	// differing values
+	ChangeModified ChangeKind = 2
+)
+
+// Change is a difference between two versions of an Alert. Info blocks are
+// identified by their language, and areas and resources by their descriptions,
+// such as alert/info[en-CA]/area[Tuolumne]/polygon. An element repeated under
+// the same name is numbered from its second occurrence, such as
+// alert/info[en-CA#2].
+type Change struct {
+	Kind ChangeKind
+	Path string
+	Old  string // Value in the old version, empty if added
+	New  string // Value in the new version, empty if removed
+}
+
+// maxChangeValue is the number of characters of each value shown by
+// Change.String.
+const maxChangeValue = 80
+
+// shorten quotes the value, truncated to maxChangeValue characters.
+func shorten(val string) string {
+	if utf8.RuneCountInString(val) > maxChangeValue {
+		val = string([]rune(val)[:maxChangeValue]) + "…"
+	}
+	return strconv.Quote(val)
+}
+
+// String returns a single line describing the Change, for logs and
+// notifications. Long values are truncated.
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return "added " + c.Path + ": " + shorten(c.New)
+	case ChangeRemoved:
+		return "removed " + c.Path + ": " + shorten(c.Old)
+	}
+	return strings.ToLower(c.Kind.String()) + " " + c.Path + ": " + shorten(c.Old) + " -> " + shorten(c.New)
+}
+
+// differ collects the changes found while comparing two Alerts.
+type differ struct {
+	changes []Change
+}
+
+// add records a change.
+func (d *differ) add(kind ChangeKind, path, old, new string) {
+	d.changes = append(d.changes, Change{Kind: kind, Path: path, Old: old, New: new})
+}
+
+// text records a change if the text of an element differs. Empty text is
+// taken as an absent element.
+func (d *differ) text(path, old, new string) {
+	switch {
+	case old == new:
+	case old == "":
+		d.add(ChangeAdded, path, old, new)
+	case new == "":
+		d.add(ChangeRemoved, path, old, new)
+	default:
+		d.add(ChangeModified, path, old, new)
+	}
+}
+
+// time records a change if two DateTimes differ in their presence or instant.
+// DateTimes written with different offsets for the same instant are equal.
+func (d *differ) time(path string, old, new DateTime) {
+	if !old.val.IsZero() && !new.val.IsZero() && old.val.Equal(new.val) {
+		return
+	}
+	d.text(path, dateTimeText(old), dateTimeText(new))
+}
+
+// dateTimeText returns the text of the DateTime, or an empty string if unset.
+func dateTimeText(t DateTime) string {
+	if t.val.IsZero() {
+		return ""
+	}
+	return t.String()
+}
+
+// set records the values removed from and added to an element that may be
+// repeated, without regard to their order.
+func (d *differ) set(path string, old, new []string) {
+	counts := make(map[string]int)
+	for _, val := range new {
+		counts[val]++
+	}
+	for _, val := range old {
+		if counts[val] > 0 {
+			counts[val]--
+			continue
+		}
+		d.add(ChangeRemoved, path, val, "")
+	}
+	counts = make(map[string]int)
+	for _, val := range old {
+		counts[val]++
+	}
+	for _, val := range new {
+		if counts[val] > 0 {
+			counts[val]--
+			continue
+		}
+		d.add(ChangeAdded, path, "", val)
+	}
+}
+
+// keyValues records the changes to key-value pairs, such as parameters. A
+// name with a single value in both versions is compared as one element, such
+// as parameter[WMOHEADER]; the values of other names are compared as sets.
+func (d *differ) keyValues(path, name string, old, new []KeyValue) {
+	oldVals, newVals := make(map[string][]string), make(map[string][]string)
+	var names []string
+	for _, pair := range old {
+		if _, ok := oldVals[pair.ValueName]; !ok {
+			names = append(names, pair.ValueName)
+		}
+		oldVals[pair.ValueName] = append(oldVals[pair.ValueName], pair.Value)
+	}
+	for _, pair := range new {
+		if _, ok := oldVals[pair.ValueName]; !ok {
+			if _, ok := newVals[pair.ValueName]; !ok {
+				names = append(names, pair.ValueName)
+			}
+		}
+		newVals[pair.ValueName] = append(newVals[pair.ValueName], pair.Value)
+	}
+	for _, key := range names {
+		keyPath := path + "/" + name + "[" + key + "]"
+		oldList, newList := oldVals[key], newVals[key]
+		if len(oldList) == 1 && len(newList) == 1 {
+			if oldList[0] != newList[0] {
+				d.add(ChangeModified, keyPath, oldList[0], newList[0])
+			}
+			continue
+		}
+		d.set(keyPath, oldList, newList)
+	}
+}
+
+// codeTexts returns the text of each code.
+func codeTexts(n int, text func(i int) string) []string {
+	vals := make([]string, n)
+	for i := range vals {
+		vals[i] = text(i)
+	}
+	return vals
+}
+
+// labels returns the label of each element, numbering repeated labels from
+// their second occurrence, such as "en-CA#2".
+func labels(keys []string) []string {
+	seen := make(map[string]int)
+	vals := make([]string, len(keys))
+	for i, key := range keys {
+		seen[key]++
+		vals[i] = key
+		if seen[key] > 1 {
+			vals[i] += "#" + strconv.Itoa(seen[key])
+		}
+	}
+	return vals
+}
+
+// Diff compares two versions of an Alert, such as an Alert and its Update, and
+// returns their differences element by element. Info blocks are matched by
+// language, areas by description or else by identical geometry, and resources
+// by description. The order of repeated codes and values is ignored.
+func Diff(old, new *Alert) []Change {
+	d := &differ{}
+	d.text("alert/identifier", old.Identifier, new.Identifier)
+	d.text("alert/sender", old.Sender, new.Sender)
+	d.time("alert/sent", old.Sent, new.Sent)
+	d.text("alert/status", old.CodeText(old.Status), new.CodeText(new.Status))
+	d.text("alert/msgType", old.CodeText(old.MsgType), new.CodeText(new.MsgType))
+	d.text("alert/source", old.Source, new.Source)
+	d.text("alert/scope", old.CodeText(old.Scope), new.CodeText(new.Scope))
+	d.text("alert/restriction", old.Restriction, new.Restriction)
+	d.set("alert/addresses", old.Addresses.Values(), new.Addresses.Values())
+	d.set("alert/code", old.Code, new.Code)
+	d.text("alert/note", old.Note, new.Note)
+	d.set("alert/references", old.References.Values(), new.References.Values())
+	d.set("alert/incidents", old.Incidents.Values(), new.Incidents.Values())
+
+	oldLabels := labels(codeTexts(len(old.Info), func(i int) string { return infoLanguage(&old.Info[i]) }))
+	newLabels := labels(codeTexts(len(new.Info), func(i int) string { return infoLanguage(&new.Info[i]) }))
+	oldIndex := make(map[string]int)
+	for i, label := range oldLabels {
+		oldIndex[label] = i
+	}
+	matched := make(map[string]bool)
+	for i, label := range newLabels {
+		path := "alert/info[" + label + "]"
+		j, ok := oldIndex[label]
+		if !ok {
+			d.add(ChangeAdded, path, "", new.Info[i].Event)
+			continue
+		}
+		matched[label] = true
+		new.Info[i].diff(d, path, &old.Info[j])
+	}
+	for i, label := range oldLabels {
+		if !matched[label] {
+			d.add(ChangeRemoved, "alert/info["+label+"]", old.Info[i].Event, "")
+		}
+	}
+	return d.changes
+}
+
+// infoLanguage returns the language of the Info, which is en-US if unset.
+func infoLanguage(info *Info) string {
+	if language := strings.TrimSpace(info.Language); language != "" {
+		return language
+	}
+	return "en-US"
+}
+
+// diff records the changes from the old version of the Info.
+func (t *Info) diff(d *differ, path string, old *Info) {
+	d.set(path+"/category",
+		codeTexts(len(old.Category), func(i int) string { return old.CodeText(old.Category[i]) }),
+		codeTexts(len(t.Category), func(i int) string { return t.CodeText(t.Category[i]) }))
+	d.text(path+"/event", old.Event, t.Event)
+	d.set(path+"/responseType",
+		codeTexts(len(old.ResponseType), func(i int) string { return old.CodeText(old.ResponseType[i]) }),
+		codeTexts(len(t.ResponseType), func(i int) string { return t.CodeText(t.ResponseType[i]) }))
+	d.text(path+"/urgency", old.CodeText(old.Urgency), t.CodeText(t.Urgency))
+	d.text(path+"/severity", old.CodeText(old.Severity), t.CodeText(t.Severity))
+	d.text(path+"/certainty", old.CodeText(old.Certainty), t.CodeText(t.Certainty))
+	d.text(path+"/audience", old.Audience, t.Audience)
+	d.keyValues(path, "eventCode", old.EventCode, t.EventCode)
+	d.time(path+"/effective", old.Effective, t.Effective)
+	d.time(path+"/onset", old.Onset, t.Onset)
+	d.time(path+"/expires", old.Expires, t.Expires)
+	d.text(path+"/senderName", old.SenderName, t.SenderName)
+	d.text(path+"/headline", old.Headline, t.Headline)
+	d.text(path+"/description", old.Description, t.Description)
+	d.text(path+"/instruction", old.Instruction, t.Instruction)
+	d.text(path+"/web", old.Web, t.Web)
+	d.text(path+"/contact", old.Contact, t.Contact)
+	d.keyValues(path, "parameter", old.Parameter, t.Parameter)
+
+	oldLabels := labels(codeTexts(len(old.Resource), func(i int) string { return old.Resource[i].ResourceDesc }))
+	newLabels := labels(codeTexts(len(t.Resource), func(i int) string { return t.Resource[i].ResourceDesc }))
+	oldIndex := make(map[string]int)
+	for i, label := range oldLabels {
+		oldIndex[label] = i
+	}
+	matched := make(map[string]bool)
+	for i, label := range newLabels {
+		resourcePath := path + "/resource[" + label + "]"
+		j, ok := oldIndex[label]
+		if !ok {
+			d.add(ChangeAdded, resourcePath, "", t.Resource[i].ResourceDesc)
+			continue
+		}
+		matched[label] = true
+		res, oldRes := &t.Resource[i], &old.Resource[j]
+		d.text(resourcePath+"/mimeType", oldRes.MimeType, res.MimeType)
+		d.text(resourcePath+"/size", sizeText(oldRes.Size), sizeText(res.Size))
+		d.text(resourcePath+"/uri", oldRes.URI, res.URI)
+		d.text(resourcePath+"/derefUri", oldRes.DerefURI, res.DerefURI)
+		d.text(resourcePath+"/digest", oldRes.Digest, res.Digest)
+	}
+	for i, label := range oldLabels {
+		if !matched[label] {
+			d.add(ChangeRemoved, path+"/resource["+label+"]", old.Resource[i].ResourceDesc, "")
+		}
+	}
+
+	t.diffAreas(d, path, old)
+}
+
+// sizeText returns the text of a resource size, or an empty string if unset.
+func sizeText(size int) string {
+	if size == 0 {
+		return ""
+	}
+	return strconv.Itoa(size)
+}
+
+// floatText returns the text of an altitude or ceiling, or an empty string if
+// unset.
+func floatText(val float32) string {
+	if val == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(float64(val), 'f', -1, 32)
+}
+
+// geometryKey returns the polygon and circles of the Area, for matching areas
+// whose descriptions have changed. It is empty if the Area has no geometry.
+func geometryKey(a *Area) string {
+	polygon := a.Polygon.String()
+	if polygon == "" && len(a.Circle) == 0 {
+		return ""
+	}
+	return polygon + "|" + strings.Join(a.Circle, "|")
+}
+
+// diffAreas records the changes to the areas of the Info. Areas are matched by
+// description, and then by identical geometry.
+func (t *Info) diffAreas(d *differ, path string, old *Info) {
+	match := make([]int, len(t.Area))
+	used := make([]bool, len(old.Area))
+	for i := range t.Area {
+		match[i] = -1
+		for j := range old.Area {
+			if !used[j] && old.Area[j].AreaDesc == t.Area[i].AreaDesc {
+				match[i], used[j] = j, true
+				break
+			}
+		}
+	}
+	for i := range t.Area {
+		key := geometryKey(&t.Area[i])
+		if match[i] >= 0 || key == "" {
+			continue
+		}
+		for j := range old.Area {
+			if !used[j] && geometryKey(&old.Area[j]) == key {
+				match[i], used[j] = j, true
+				break
+			}
+		}
+	}
+
+	newLabels := labels(codeTexts(len(t.Area), func(i int) string { return t.Area[i].AreaDesc }))
+	for i, label := range newLabels {
+		areaPath := path + "/area[" + label + "]"
+		if match[i] < 0 {
+			d.add(ChangeAdded, areaPath, "", t.Area[i].AreaDesc)
+			continue
+		}
+		area, oldArea := &t.Area[i], &old.Area[match[i]]
+		d.text(areaPath+"/areaDesc", oldArea.AreaDesc, area.AreaDesc)
+		d.text(areaPath+"/polygon", oldArea.Polygon.String(), area.Polygon.String())
+		d.set(areaPath+"/circle", oldArea.Circle, area.Circle)
+		d.keyValues(areaPath, "geocode", oldArea.Geocode, area.Geocode)
+		d.text(areaPath+"/altitude", floatText(oldArea.Altitude), floatText(area.Altitude))
+		d.text(areaPath+"/ceiling", floatText(oldArea.Ceiling), floatText(area.Ceiling))
+	}
+	oldLabels := labels(codeTexts(len(old.Area), func(i int) string { return old.Area[i].AreaDesc }))
+	for j, label := range oldLabels {
+		if !used[j] {
+			d.add(ChangeRemoved, path+"/area["+label+"]", old.Area[j].AreaDesc, "")
+		}
+	}
+}
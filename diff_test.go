@@ -0,0 +1,97 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thetannerryan/cap"
+)
+
+// parseThunderstorm returns the thunderstorm example alert.
+func parseThunderstorm() *cap.Alert {
+	contents, err := ioutil.ReadFile("testing/Oasis_ThunderstormWarning.xml")
+	if err != nil {
+		panic(err)
+	}
+	alert, err := cap.ParseCAP(contents)
+	if err != nil {
+		panic(err)
+	}
+	return alert
+}
+
+// diffText returns the changes, one per line.
+func diffText(changes []cap.Change) string {
+	lines := make([]string, len(changes))
+	for i, change := range changes {
+		lines[i] = change.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TestDiff tests the changes between the thunderstorm example and an edited
+// version of it.
+func TestDiff(t *testing.T) {
+	old, new := parseThunderstorm(), parseThunderstorm()
+	test(t, "No changes", "", diffText(cap.Diff(old, new)))
+
+	info := &new.Info[0]
+	info.Severity = cap.SeverityExtreme
+	info.Expires = cap.NewDateTime(info.Expires.Time().Add(time.Hour))
+	info.Instruction = "TAKE COVER NOW."
+	info.Contact = ""
+	info.Area[0].AreaDesc = "TUOLUMNE, CALAVERAS AND ALPINE COUNTIES"
+	info.Area[0].Geocode = info.Area[0].Geocode[:2]
+	info.Area = append(info.Area, cap.Area{AreaDesc: "MONO COUNTY"})
+	info.EventCode[0].Value = "TOR"
+	new.Note = "Upgraded"
+
+	expected := strings.Join([]string{
+		`added alert/note: "Upgraded"`,
+		`modified alert/info[en-US]/severity: "Severe" -> "Extreme"`,
+		`modified alert/info[en-US]/eventCode[SAME]: "SVR" -> "TOR"`,
+		`modified alert/info[en-US]/expires: "2003-06-17T16:00:00-07:00" -> "2003-06-17T17:00:00-07:00"`,
+		`modified alert/info[en-US]/instruction: "TAKE COVER IN A SUBSTANTIAL SHELTER UNTIL THE STORM PASSES." -> "TAKE COVER NOW."`,
+		`removed alert/info[en-US]/contact: "BARUFFALDI/JUSKIE"`,
+		`modified alert/info[en-US]/area[TUOLUMNE, CALAVERAS AND ALPINE COUNTIES]/areaDesc: "EXTREME NORTH CENTRAL TUOLUMNE COUNTY IN CALIFORNIA, EXTREME NORTHEASTERN CALAVE…" -> "TUOLUMNE, CALAVERAS AND ALPINE COUNTIES"`,
+		`removed alert/info[en-US]/area[TUOLUMNE, CALAVERAS AND ALPINE COUNTIES]/geocode[SAME]: "006003"`,
+		`added alert/info[en-US]/area[MONO COUNTY]: "MONO COUNTY"`,
+	}, "\n")
+	changes := cap.Diff(old, new)
+	test(t, "Changes", expected, diffText(changes))
+	test(t, "Full value", old.Info[0].Area[0].AreaDesc, changes[6].Old)
+	test(t, "Kind", "Modified", changes[1].Kind.String())
+}
+
+// TestDiffInfo tests that Info blocks are matched by language.
+func TestDiffInfo(t *testing.T) {
+	old, new := parseThunderstorm(), parseThunderstorm()
+	french := old.Info[0]
+	french.Language = "fr-CA"
+	french.Event = "ORAGE VIOLENT"
+	old.Info = append(old.Info, french)
+	new.Info = append(new.Info, new.Info[0])
+
+	// the expiry is written with another offset for the same instant
+	expires, err := cap.ParseDateTime("2003-06-17T23:00:00-00:00")
+	if err != nil {
+		panic(err)
+	}
+	new.Info[0].Expires = expires
+
+	expected := strings.Join([]string{
+		`added alert/info[en-US#2]: "SEVERE THUNDERSTORM"`,
+		`removed alert/info[fr-CA]: "ORAGE VIOLENT"`,
+	}, "\n")
+	test(t, "Changes", expected, diffText(cap.Diff(old, new)))
+
+	new.Info = nil
+	test(t, "Removed infos", "2", fmt.Sprint(len(cap.Diff(old, new))))
+}
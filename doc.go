@@ -34,6 +34,65 @@ Here is a simple example of reading the alert headline.
         fmt.Println(alert.Info[0].Headline)
     }
 
+Feeds do not always follow the specification. `ParseCAPWithOptions` with
+`ParseOptions{Lenient: true}` keeps unrecognized code values, matches codes
+without regard to case, and returns each deviation as a `Warning` rather than
+failing the parse. The text of a kept code is held by the Alert or Info that
+contains it, and is returned by their `CodeText` methods.
+
+`ParseOptions{TolerantDateTime: true}` similarly accepts dateTime values with
+a Z suffix, a missing seconds field, or an offset without a colon. Parsed
+dateTime values keep their fractional seconds and the sign of a zero offset,
+so that re-marshaled alerts match the original text.
+
+Errors returned by the parsers are of type `*ParseError`, carrying the path,
+line and column of the offending element. Use `errors.Is` with
+`ErrIllegalEnumValue` or `ErrBadDateTime` to test for common causes.
+
+New alerts may be composed with `NewAlert`, which returns a builder. `Build`
+checks the result with `Validate`, and requires the urgency, severity and
+certainty of each Info block to be set explicitly.
+
+    builder := cap.NewAlert("cap@example.org")
+    builder.AddInfo().
+        Category(cap.CategoryMet).
+        Event("wind").
+        Urgency(cap.UrgencyFuture).
+        Severity(cap.SeverityModerate).
+        Certainty(cap.CertaintyLikely).
+        AddArea("Mont-Tremblant").
+        Circle(cap.Point{Latitude: 46.2, Longitude: -74.5}, 10)
+    alert, err := builder.Build()
+
+`MarshalJSON` and `UnmarshalJSON` convert an Alert to and from a JSON form
+for interchange, described by the JSON Schema in schema/alert.schema.json.
+Lists are arrays, unset elements are omitted, and polygons and circles are
+given as coordinates. The form round-trips with the XML form: an Alert read
+from JSON marshals to the same elements as the original XML, except that empty
+elements are omitted. Codes kept by lenient parsing are outside the schema, so
+`MarshalJSON` rejects them.
+
+`Diff` compares two versions of an alert, such as an alert and its update, and
+returns a `Change` for each element added, removed or modified. Each Change
+prints as a single line for logs and notifications.
+
+    for _, change := range cap.Diff(alert, update) {
+        log.Println(change)
+    }
+
+An alert received from several sources may be recognized by its `Key`, the
+sender, identifier and sent time, and its `ContentHash`, which ignores
+whitespace, the order of repeated elements and the formatting of times and
+coordinates. A `Deduper` remembers recent alerts for stream processing.
+
+    deduper := cap.NewDeduper(24*time.Hour, 100000, nil)
+    switch deduper.Check(alert) {
+    case cap.SeenDuplicate:
+        return
+    case cap.SeenConflict:
+        log.Println("conflicting copies of", alert.Key())
+    }
+
 License
 
 Copyright (c) 2019 Tanner Ryan. All rights reserved. Use of this source code is
@@ -0,0 +1,333 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dsig
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+// xmlNamespace is the namespace bound to the reserved xml prefix.
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// node is an element of a parsed document. Prefixes are kept as written, so
+// that the element may be canonicalized.
+type node struct {
+	parent   *node
+	prefix   string
+	local    string
+	space    string            // Namespace of the element
+	decls    map[string]string // Namespaces declared on the element, by prefix
+	attrs    []xml.Attr        // Attributes other than declarations, by prefix
+	children []interface{}     // *node, xml.CharData or xml.ProcInst
+}
+
+// lookup returns the namespace bound to the prefix in the scope of the node.
+func (n *node) lookup(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return xmlNamespace, true
+	}
+	for ; n != nil; n = n.parent {
+		if space, ok := n.decls[prefix]; ok {
+			return space, true
+		}
+	}
+	return "", prefix == ""
+}
+
+// attr returns the value of the unprefixed attribute.
+func (n *node) attr(local string) string {
+	for _, attr := range n.attrs {
+		if attr.Name.Space == "" && attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// child returns the first child element with the namespace and local name.
+func (n *node) child(space, local string) *node {
+	for _, child := range n.children {
+		if elem, ok := child.(*node); ok && elem.space == space && elem.local == local {
+			return elem
+		}
+	}
+	return nil
+}
+
+// text returns the character data of the node and its descendants.
+func (n *node) text() string {
+	var buff strings.Builder
+	for _, child := range n.children {
+		switch child := child.(type) {
+		case *node:
+			buff.WriteString(child.text())
+		case xml.CharData:
+			buff.Write(child)
+		}
+	}
+	return buff.String()
+}
+
+// walk calls fn for the node and each of its descendant elements, in document
+// order.
+func (n *node) walk(fn func(*node)) {
+	fn(n)
+	for _, child := range n.children {
+		if elem, ok := child.(*node); ok {
+			elem.walk(fn)
+		}
+	}
+}
+
+// parseTree parses the document and returns its root element. Comments are
+// discarded, as only the canonicalization methods without comments are
+// supported.
+func parseTree(data []byte) (*node, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var root, current *node
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			elem := &node{parent: current, prefix: token.Name.Space, local: token.Name.Local, decls: make(map[string]string)}
+			for _, attr := range token.Attr {
+				switch {
+				case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					elem.decls[""] = attr.Value
+				case attr.Name.Space == "xmlns":
+					elem.decls[attr.Name.Local] = attr.Value
+				default:
+					elem.attrs = append(elem.attrs, attr)
+				}
+			}
+			space, ok := elem.lookup(elem.prefix)
+			if !ok {
+				return nil, errors.New("Error: undeclared namespace prefix " + elem.prefix)
+			}
+			elem.space = space
+			if current == nil {
+				if root != nil {
+					return nil, errors.New("Error: multiple root elements")
+				}
+				root = elem
+			} else {
+				current.children = append(current.children, elem)
+			}
+			current = elem
+		case xml.EndElement:
+			if current == nil {
+				return nil, errors.New("Error: unexpected end element " + token.Name.Local)
+			}
+			current = current.parent
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, token.Copy())
+			}
+		case xml.ProcInst:
+			if current != nil {
+				current.children = append(current.children, token.Copy())
+			}
+		}
+	}
+	if root == nil || current != nil {
+		return nil, errors.New("Error: incomplete document")
+	}
+	return root, nil
+}
+
+// canonicalizer writes a subtree in Canonical XML 1.0, either inclusive or
+// exclusive, without comments.
+type canonicalizer struct {
+	buff      bytes.Buffer
+	exclusive bool
+	exclude   map[*node]bool // Subtrees omitted from the output, such as enveloped signatures
+}
+
+// canonicalize returns the canonical form of the subtree, omitting the
+// excluded subtrees.
+func canonicalize(n *node, exclusive bool, exclude map[*node]bool) []byte {
+	c := &canonicalizer{exclusive: exclusive, exclude: exclude}
+	c.element(n, map[string]string{"": ""}, true)
+	return c.buff.Bytes()
+}
+
+// inScope returns the namespaces in scope of the node, by prefix.
+func inScope(n *node) map[string]string {
+	scope := make(map[string]string)
+	var chain []*node
+	for ; n != nil; n = n.parent {
+		chain = append(chain, n)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		for prefix, space := range chain[i].decls {
+			scope[prefix] = space
+		}
+	}
+	return scope
+}
+
+// element writes the element and its content. The rendered map holds the
+// namespaces declared by the output ancestors of the element.
+func (c *canonicalizer) element(n *node, rendered map[string]string, apex bool) {
+	if c.exclude[n] {
+		return
+	}
+
+	// namespace declarations
+	var candidates map[string]string
+	if c.exclusive {
+		candidates = make(map[string]string)
+		space, _ := n.lookup(n.prefix)
+		candidates[n.prefix] = space
+		for _, attr := range n.attrs {
+			if attr.Name.Space != "" && attr.Name.Space != "xml" {
+				space, _ := n.lookup(attr.Name.Space)
+				candidates[attr.Name.Space] = space
+			}
+		}
+	} else if apex {
+		// the apex of an inclusive subtree declares every namespace in scope
+		candidates = inScope(n)
+	} else {
+		candidates = n.decls
+	}
+	var prefixes []string
+	for prefix, space := range candidates {
+		if prefix == "xml" {
+			continue
+		}
+		if current, ok := rendered[prefix]; ok && current == space {
+			continue
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	scope := rendered
+	if len(prefixes) > 0 {
+		scope = make(map[string]string, len(rendered)+len(prefixes))
+		for prefix, space := range rendered {
+			scope[prefix] = space
+		}
+		for _, prefix := range prefixes {
+			scope[prefix] = candidates[prefix]
+		}
+	}
+
+	c.buff.WriteByte('<')
+	c.name(n.prefix, n.local)
+	for _, prefix := range prefixes {
+		if prefix == "" {
+			c.buff.WriteString(` xmlns="`)
+		} else {
+			c.buff.WriteString(` xmlns:` + prefix + `="`)
+		}
+		escapeAttr(&c.buff, candidates[prefix])
+		c.buff.WriteByte('"')
+	}
+
+	// attributes, ordered by namespace and then local name
+	attrs := make([]xml.Attr, len(n.attrs))
+	copy(attrs, n.attrs)
+	spaces := make(map[string]string)
+	for _, attr := range attrs {
+		if attr.Name.Space != "" {
+			space, _ := n.lookup(attr.Name.Space)
+			spaces[attr.Name.Space] = space
+		}
+	}
+	sort.SliceStable(attrs, func(i, j int) bool {
+		si, sj := spaces[attrs[i].Name.Space], spaces[attrs[j].Name.Space]
+		if si != sj {
+			return si < sj
+		}
+		return attrs[i].Name.Local < attrs[j].Name.Local
+	})
+	for _, attr := range attrs {
+		c.buff.WriteByte(' ')
+		c.name(attr.Name.Space, attr.Name.Local)
+		c.buff.WriteString(`="`)
+		escapeAttr(&c.buff, attr.Value)
+		c.buff.WriteByte('"')
+	}
+	c.buff.WriteByte('>')
+
+	for _, child := range n.children {
+		switch child := child.(type) {
+		case *node:
+			c.element(child, scope, false)
+		case xml.CharData:
+			escapeText(&c.buff, child)
+		case xml.ProcInst:
+			c.buff.WriteString("<?" + child.Target)
+			if len(child.Inst) > 0 {
+				c.buff.WriteByte(' ')
+				c.buff.Write(child.Inst)
+			}
+			c.buff.WriteString("?>")
+		}
+	}
+	c.buff.WriteString("</")
+	c.name(n.prefix, n.local)
+	c.buff.WriteByte('>')
+}
+
+// name writes a qualified name.
+func (c *canonicalizer) name(prefix, local string) {
+	if prefix != "" {
+		c.buff.WriteString(prefix + ":")
+	}
+	c.buff.WriteString(local)
+}
+
+// escapeText writes character data, escaped as required by Canonical XML.
+func escapeText(buff *bytes.Buffer, text []byte) {
+	for _, b := range text {
+		switch b {
+		case '&':
+			buff.WriteString("&amp;")
+		case '<':
+			buff.WriteString("&lt;")
+		case '>':
+			buff.WriteString("&gt;")
+		case '\r':
+			buff.WriteString("&#xD;")
+		default:
+			buff.WriteByte(b)
+		}
+	}
+}
+
+// escapeAttr writes an attribute value, escaped as required by Canonical XML.
+func escapeAttr(buff *bytes.Buffer, val string) {
+	for i := 0; i < len(val); i++ {
+		switch b := val[i]; b {
+		case '&':
+			buff.WriteString("&amp;")
+		case '<':
+			buff.WriteString("&lt;")
+		case '"':
+			buff.WriteString("&quot;")
+		case '\t':
+			buff.WriteString("&#x9;")
+		case '\n':
+			buff.WriteString("&#xA;")
+		case '\r':
+			buff.WriteString("&#xD;")
+		default:
+			buff.WriteByte(b)
+		}
+	}
+}
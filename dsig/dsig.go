@@ -0,0 +1,440 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package dsig checks and creates the XML digital signatures of CAP alerts, as
+required by CAP-CP and enforced by NAADS.
+
+Check verifies every signature of an alert document against a pool of trusted
+certificate authorities. Each signature must reference the alert and carry the
+certificate of its signer within its KeyInfo. Canonical XML 1.0, inclusive or
+exclusive and without comments, the enveloped signature transform, SHA digests,
+and RSA or ECDSA signature methods are supported.
+
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(bundle)
+	results, err := dsig.Check(data, x509.VerifyOptions{Roots: roots})
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		fmt.Println(result.ID, result.Err)
+	}
+
+A Verifier may be set on a capserver Handler to reject unsigned alerts, and
+Sign appends an enveloped signature to an outgoing alert.
+*/
+package dsig
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"strings"
+
+	// register the hash functions of the supported algorithms
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+
+	"github.com/thetannerryan/cap"
+)
+
+// Namespace is the namespace of XML digital signatures.
+const Namespace = "http://www.w3.org/2000/09/xmldsig#"
+
+// Algorithm identifiers.
+const (
+	C14N          = "http://www.w3.org/TR/2001/REC-xml-c14n-20010315"
+	ExclusiveC14N = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	EnvelopedSig  = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	SHA1          = "http://www.w3.org/2000/09/xmldsig#sha1"
+	SHA256        = "http://www.w3.org/2001/04/xmlenc#sha256"
+	SHA384        = "http://www.w3.org/2001/04/xmldsig-more#sha384"
+	SHA512        = "http://www.w3.org/2001/04/xmlenc#sha512"
+	RSASHA1       = "http://www.w3.org/2000/09/xmldsig#rsa-sha1"
+	RSASHA256     = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	RSASHA384     = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha384"
+	RSASHA512     = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha512"
+	ECDSASHA256   = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256"
+	ECDSASHA384   = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha384"
+	ECDSASHA512   = "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha512"
+)
+
+// Sentinel errors, which may be tested for with errors.Is.
+var (
+	// ErrNotSigned is returned for alerts without any signature.
+	ErrNotSigned = errors.New("Error: alert is not signed")
+	// ErrDigestMismatch is returned when the signed content has been altered.
+	ErrDigestMismatch = errors.New("Error: digest of signed content does not match")
+	// ErrBadSignature is returned when the signature value does not match the
+	// signed info and the key of the certificate.
+	ErrBadSignature = errors.New("Error: signature value does not match")
+)
+
+// digests maps digest methods to their hash functions.
+var digests = map[string]crypto.Hash{
+	SHA1:   crypto.SHA1,
+	SHA256: crypto.SHA256,
+	SHA384: crypto.SHA384,
+	SHA512: crypto.SHA512,
+}
+
+// signatureMethods maps signature methods to their hash functions.
+var signatureMethods = map[string]crypto.Hash{
+	RSASHA1:     crypto.SHA1,
+	RSASHA256:   crypto.SHA256,
+	RSASHA384:   crypto.SHA384,
+	RSASHA512:   crypto.SHA512,
+	ECDSASHA256: crypto.SHA256,
+	ECDSASHA384: crypto.SHA384,
+	ECDSASHA512: crypto.SHA512,
+}
+
+// Result is the outcome of checking one signature of an alert.
+type Result struct {
+	ID          string            // Id attribute of the Signature, if any
+	Certificate *x509.Certificate // Certificate of the signer, if it could be read
+	Err         error             // Reason the signature is not valid, or nil
+}
+
+// Check verifies every signature within the alert document. The certificate
+// of each signer is verified with the options, such as against a pool of
+// trusted roots. If the options give no key usages, any usage is accepted. An
+// error is returned only if the document cannot be read or has no signature;
+// the problems of each signature are given by its Result.
+func Check(data []byte, opts x509.VerifyOptions) ([]Result, error) {
+	root, err := parseTree(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.KeyUsages) == 0 {
+		opts.KeyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageAny}
+	}
+	var results []Result
+	root.walk(func(n *node) {
+		if n.space == Namespace && n.local == "Signature" {
+			results = append(results, checkSignature(root, n, opts))
+		}
+	})
+	if len(results) == 0 {
+		return nil, ErrNotSigned
+	}
+	return results, nil
+}
+
+// checkSignature verifies the Signature element of the document.
+func checkSignature(root, sig *node, opts x509.VerifyOptions) Result {
+	result := Result{ID: sig.attr("Id")}
+	signedInfo := sig.child(Namespace, "SignedInfo")
+	if signedInfo == nil {
+		result.Err = errors.New("Error: missing SignedInfo")
+		return result
+	}
+
+	// the certificate of the signer, followed by any intermediates
+	var certs []*x509.Certificate
+	if keyInfo := sig.child(Namespace, "KeyInfo"); keyInfo != nil {
+		keyInfo.walk(func(n *node) {
+			if n.space != Namespace || n.local != "X509Certificate" {
+				return
+			}
+			der, err := decodeBase64(n.text())
+			if err != nil {
+				return
+			}
+			if cert, err := x509.ParseCertificate(der); err == nil {
+				certs = append(certs, cert)
+			}
+		})
+	}
+	if len(certs) == 0 {
+		result.Err = errors.New("Error: missing X509Certificate")
+		return result
+	}
+	result.Certificate = certs[0]
+
+	// every reference must match its digest
+	references := 0
+	for _, child := range signedInfo.children {
+		if ref, ok := child.(*node); ok && ref.space == Namespace && ref.local == "Reference" {
+			if err := checkReference(root, sig, ref); err != nil {
+				result.Err = err
+				return result
+			}
+			references++
+		}
+	}
+	if references == 0 {
+		result.Err = errors.New("Error: missing Reference")
+		return result
+	}
+
+	// the signature value over the canonical signed info
+	exclusive, err := c14nMethod(algorithm(signedInfo.child(Namespace, "CanonicalizationMethod")))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	method := algorithm(signedInfo.child(Namespace, "SignatureMethod"))
+	hash, ok := signatureMethods[method]
+	if !ok {
+		result.Err = errors.New("Error: unsupported signature method " + method)
+		return result
+	}
+	value := sig.child(Namespace, "SignatureValue")
+	if value == nil {
+		result.Err = errors.New("Error: missing SignatureValue")
+		return result
+	}
+	signature, err := decodeBase64(value.text())
+	if err != nil {
+		result.Err = errors.New("Error: malformed SignatureValue")
+		return result
+	}
+	h := hash.New()
+	h.Write(canonicalize(signedInfo, exclusive, nil))
+	if !verifySignature(certs[0].PublicKey, hash, h.Sum(nil), signature) {
+		result.Err = ErrBadSignature
+		return result
+	}
+
+	// the certificate must chain to a trusted root
+	if len(certs) > 1 {
+		intermediates := x509.NewCertPool()
+		if opts.Intermediates != nil {
+			intermediates = opts.Intermediates.Clone()
+		}
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		opts.Intermediates = intermediates
+	}
+	if _, err := certs[0].Verify(opts); err != nil {
+		result.Err = err
+	}
+	return result
+}
+
+// checkReference verifies the digest of a Reference within the Signature,
+// which must reference the alert element itself.
+func checkReference(root, sig, ref *node) error {
+	uri := ref.attr("URI")
+	target := root
+	if uri != "" {
+		if !strings.HasPrefix(uri, "#") {
+			return errors.New("Error: unsupported reference " + uri)
+		}
+		// only the alert as a whole may be signed, so that no part of it is
+		// left uncovered by the signature
+		if id := uri[1:]; root.attr("Id") != id && root.attr("ID") != id && root.attr("id") != id {
+			return errors.New("Error: reference " + uri + " is not the alert")
+		}
+	}
+
+	enveloped := false
+	exclusive := false
+	if transforms := ref.child(Namespace, "Transforms"); transforms != nil {
+		for _, child := range transforms.children {
+			transform, ok := child.(*node)
+			if !ok || transform.space != Namespace || transform.local != "Transform" {
+				continue
+			}
+			switch method := algorithm(transform); method {
+			case EnvelopedSig:
+				enveloped = true
+			case C14N, ExclusiveC14N:
+				exclusive = method == ExclusiveC14N
+			default:
+				return errors.New("Error: unsupported transform " + method)
+			}
+		}
+	}
+	method := algorithm(ref.child(Namespace, "DigestMethod"))
+	hash, ok := digests[method]
+	if !ok {
+		return errors.New("Error: unsupported digest method " + method)
+	}
+	value := ref.child(Namespace, "DigestValue")
+	if value == nil {
+		return errors.New("Error: missing DigestValue")
+	}
+	expected, err := decodeBase64(value.text())
+	if err != nil {
+		return errors.New("Error: malformed DigestValue")
+	}
+	if !enveloped {
+		return matchDigest(hash, canonicalize(target, exclusive, nil), expected)
+	}
+	err = matchDigest(hash, canonicalize(target, exclusive, map[*node]bool{sig: true}), expected)
+	if err == nil {
+		return nil
+	}
+	// NAADS digests each signed alert with every signature removed, rather
+	// than only the enveloping one
+	siblings := make(map[*node]bool)
+	for _, child := range target.children {
+		if elem, ok := child.(*node); ok && elem.space == Namespace && elem.local == "Signature" {
+			siblings[elem] = true
+		}
+	}
+	if len(siblings) < 2 || !siblings[sig] {
+		return err
+	}
+	return matchDigest(hash, canonicalize(target, exclusive, siblings), expected)
+}
+
+// matchDigest returns ErrDigestMismatch if the digest of the canonical content
+// is not the expected value.
+func matchDigest(hash crypto.Hash, content, expected []byte) error {
+	h := hash.New()
+	h.Write(content)
+	if !bytes.Equal(h.Sum(nil), expected) {
+		return ErrDigestMismatch
+	}
+	return nil
+}
+
+// algorithm returns the Algorithm attribute of the element, if present.
+func algorithm(n *node) string {
+	if n == nil {
+		return ""
+	}
+	return n.attr("Algorithm")
+}
+
+// c14nMethod reports whether the canonicalization method is exclusive.
+func c14nMethod(method string) (bool, error) {
+	switch method {
+	case C14N:
+		return false, nil
+	case ExclusiveC14N:
+		return true, nil
+	}
+	return false, errors.New("Error: unsupported canonicalization method " + method)
+}
+
+// decodeBase64 decodes base64 text, ignoring whitespace.
+func decodeBase64(text string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(text), ""))
+}
+
+// verifySignature reports whether the signature of the hashed data matches the
+// public key. ECDSA signatures are the concatenated r and s values.
+func verifySignature(key crypto.PublicKey, hash crypto.Hash, hashed, signature []byte) bool {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, hash, hashed, signature) == nil
+	case *ecdsa.PublicKey:
+		if len(signature)%2 != 0 {
+			return false
+		}
+		half := len(signature) / 2
+		r := new(big.Int).SetBytes(signature[:half])
+		s := new(big.Int).SetBytes(signature[half:])
+		return ecdsa.Verify(key, hashed, r, s)
+	}
+	return false
+}
+
+// Verifier checks that an alert has at least one signature, and that every
+// signature is valid and trusted. It may be used as the Verifier of a
+// capserver Handler.
+type Verifier struct {
+	Options x509.VerifyOptions // Options used to verify each certificate
+}
+
+// Verify returns the error of the first signature that is not valid, or
+// ErrNotSigned if the alert has no signature.
+func (v *Verifier) Verify(data []byte, alert *cap.Alert) error {
+	results, err := Check(data, v.Options)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+	return nil
+}
+
+// Sign appends an enveloped signature to the alert document, made with the
+// key of the first certificate of the chain. The alert is canonicalized with
+// exclusive Canonical XML and digested with SHA-256. RSA keys sign with
+// RSA-SHA256 and ECDSA keys with ECDSA-SHA256. The whole chain is included
+// within the KeyInfo.
+func Sign(data []byte, key crypto.Signer, chain []*x509.Certificate) ([]byte, error) {
+	if len(chain) == 0 {
+		return nil, errors.New("Error: missing certificate")
+	}
+	var method string
+	switch key.Public().(type) {
+	case *rsa.PublicKey:
+		method = RSASHA256
+	case *ecdsa.PublicKey:
+		method = ECDSASHA256
+	default:
+		return nil, errors.New("Error: unsupported key type")
+	}
+	root, err := parseTree(data)
+	if err != nil {
+		return nil, err
+	}
+	end := bytes.LastIndex(data, []byte("</"))
+	if end < 0 {
+		return nil, errors.New("Error: incomplete document")
+	}
+	digest := crypto.SHA256.New()
+	digest.Write(canonicalize(root, true, nil))
+
+	var signedInfo strings.Builder
+	signedInfo.WriteString(`<SignedInfo><CanonicalizationMethod Algorithm="` + ExclusiveC14N + `"></CanonicalizationMethod>`)
+	signedInfo.WriteString(`<SignatureMethod Algorithm="` + method + `"></SignatureMethod>`)
+	signedInfo.WriteString(`<Reference URI=""><Transforms><Transform Algorithm="` + EnvelopedSig + `"></Transform>`)
+	signedInfo.WriteString(`<Transform Algorithm="` + ExclusiveC14N + `"></Transform></Transforms>`)
+	signedInfo.WriteString(`<DigestMethod Algorithm="` + SHA256 + `"></DigestMethod>`)
+	signedInfo.WriteString(`<DigestValue>` + base64.StdEncoding.EncodeToString(digest.Sum(nil)) + `</DigestValue></Reference></SignedInfo>`)
+
+	// the signed info as it will be canonicalized within the signature
+	tree, err := parseTree([]byte(`<Signature xmlns="` + Namespace + `">` + signedInfo.String() + `</Signature>`))
+	if err != nil {
+		return nil, err
+	}
+	hashed := crypto.SHA256.New()
+	hashed.Write(canonicalize(tree.child(Namespace, "SignedInfo"), true, nil))
+	signature, err := key.Sign(rand.Reader, hashed.Sum(nil), crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	if pub, ok := key.Public().(*ecdsa.PublicKey); ok {
+		var parsed struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(signature, &parsed); err != nil {
+			return nil, err
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		r, s := parsed.R.Bytes(), parsed.S.Bytes()
+		signature = make([]byte, 2*size)
+		copy(signature[size-len(r):size], r)
+		copy(signature[2*size-len(s):], s)
+	}
+
+	var buff bytes.Buffer
+	buff.Write(data[:end])
+	buff.WriteString(`<Signature xmlns="` + Namespace + `">` + signedInfo.String())
+	buff.WriteString(`<SignatureValue>` + base64.StdEncoding.EncodeToString(signature) + `</SignatureValue><KeyInfo><X509Data>`)
+	for _, cert := range chain {
+		buff.WriteString(`<X509Certificate>` + base64.StdEncoding.EncodeToString(cert.Raw) + `</X509Certificate>`)
+	}
+	buff.WriteString(`</X509Data></KeyInfo></Signature>`)
+	buff.Write(data[end:])
+	return buff.Bytes(), nil
+}
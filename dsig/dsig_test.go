@@ -0,0 +1,198 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dsig_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/thetannerryan/cap"
+	"github.com/thetannerryan/cap/dsig"
+)
+
+// test is a helper for the tests.
+func test(t *testing.T, name, expected, actual string) {
+	fmt.Printf(">> Testing %s\nExpected: %s\nActual:   %s\n", name, expected, actual)
+	if expected != actual {
+		t.Errorf("Incorrect output")
+	}
+}
+
+// fixture returns the contents of an example alert.
+func fixture(name string) []byte {
+	contents, err := ioutil.ReadFile("../testing/" + name + ".xml")
+	if err != nil {
+		panic(err)
+	}
+	return contents
+}
+
+// TestNAADS tests the two signatures of the NAADS example, trusting the
+// certificates of their signers while they were valid.
+func TestNAADS(t *testing.T) {
+	data := fixture("PelmorexNAADS_WindWarning")
+	roots := x509.NewCertPool()
+	results, err := dsig.Check(data, x509.VerifyOptions{Roots: roots})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Signatures", "2", fmt.Sprint(len(results)))
+	for _, result := range results {
+		roots.AddCert(result.Certificate)
+	}
+	opts := x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	results, err = dsig.Check(data, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "NAADS id", "NAADS Signature", results[0].ID)
+	test(t, "NAADS valid", "<nil>", fmt.Sprint(results[0].Err))
+	test(t, "Issuer id", "Environment Canada", results[1].ID)
+	test(t, "Issuer valid", "<nil>", fmt.Sprint(results[1].Err))
+	test(t, "Issuer subject", "meteo.gc.ca", results[1].Certificate.Subject.CommonName)
+
+	altered := bytes.Replace(data, []byte("<urgency>Future</urgency>"), []byte("<urgency>Immediate</urgency>"), 1)
+	results, err = dsig.Check(altered, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Altered", "true", fmt.Sprint(errors.Is(results[0].Err, dsig.ErrDigestMismatch)))
+
+	verifier := &dsig.Verifier{Options: opts}
+	test(t, "Verifier", "<nil>", fmt.Sprint(verifier.Verify(data, nil)))
+	test(t, "Verifier altered", dsig.ErrDigestMismatch.Error(), fmt.Sprint(verifier.Verify(altered, nil)))
+	test(t, "Unsigned", dsig.ErrNotSigned.Error(), fmt.Sprint(verifier.Verify(fixture("Oasis_ThunderstormWarning"), nil)))
+}
+
+// certificate returns a certificate for the key, issued by the parent. If the
+// parent is nil, the certificate is a self-signed authority.
+func certificate(name string, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		panic(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(err)
+	}
+	return cert
+}
+
+// TestSign tests that signed alerts are accepted only with their authority.
+func TestSign(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	ca := certificate("Example CA", caKey, nil, nil)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	for _, key := range []crypto.Signer{ecKey, rsaKey} {
+		cert := certificate("cap@example.org", key, ca, caKey)
+		signed, err := dsig.Sign(fixture("Oasis_ThunderstormWarning"), key, []*x509.Certificate{cert})
+		if err != nil {
+			t.Fatal(err)
+		}
+		alert, err := cap.ParseCAP(signed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		kind := fmt.Sprintf("%T ", key)
+		test(t, kind+"parsed signatures", "1", fmt.Sprint(len(alert.Signature)))
+
+		verifier := &dsig.Verifier{Options: x509.VerifyOptions{Roots: roots}}
+		test(t, kind+"trusted", "<nil>", fmt.Sprint(verifier.Verify(signed, alert)))
+		verifier.Options.Roots = x509.NewCertPool()
+		test(t, kind+"untrusted", "true", fmt.Sprint(verifier.Verify(signed, alert) != nil))
+
+		altered := bytes.Replace(signed, []byte("BARUFFALDI"), []byte("BARUFFALDO"), 1)
+		results, err := dsig.Check(altered, x509.VerifyOptions{Roots: roots})
+		if err != nil {
+			t.Fatal(err)
+		}
+		test(t, kind+"altered", dsig.ErrDigestMismatch.Error(), fmt.Sprint(results[0].Err))
+	}
+}
+
+// TestPartialReference tests that a signature covering only part of the alert
+// is rejected, even though its digest and value match.
+func TestPartialReference(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	cert := certificate("cap@example.org", key, nil, nil)
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	// the info is written in its canonical form, so that its digest may be
+	// taken directly
+	info := `<info xmlns="urn:oasis:names:tc:emergency:cap:1.2" Id="part"><event>Test</event></info>`
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(info))
+	signedInfo := `<SignedInfo xmlns="` + dsig.Namespace + `"><CanonicalizationMethod Algorithm="` + dsig.ExclusiveC14N + `"></CanonicalizationMethod>` +
+		`<SignatureMethod Algorithm="` + dsig.ECDSASHA256 + `"></SignatureMethod>` +
+		`<Reference URI="#part"><Transforms><Transform Algorithm="` + dsig.ExclusiveC14N + `"></Transform></Transforms>` +
+		`<DigestMethod Algorithm="` + dsig.SHA256 + `"></DigestMethod>` +
+		`<DigestValue>` + base64.StdEncoding.EncodeToString(digest.Sum(nil)) + `</DigestValue></Reference></SignedInfo>`
+	hashed := sha256.Sum256([]byte(signedInfo))
+	r, s, err := ecdsa.Sign(rand.Reader, key, hashed[:])
+	if err != nil {
+		panic(err)
+	}
+	value := make([]byte, 64)
+	copy(value[32-len(r.Bytes()):32], r.Bytes())
+	copy(value[64-len(s.Bytes()):], s.Bytes())
+
+	data := []byte(`<alert xmlns="urn:oasis:names:tc:emergency:cap:1.2"><identifier>TEST</identifier>` + info +
+		`<Signature xmlns="` + dsig.Namespace + `">` + signedInfo +
+		`<SignatureValue>` + base64.StdEncoding.EncodeToString(value) + `</SignatureValue>` +
+		`<KeyInfo><X509Data><X509Certificate>` + base64.StdEncoding.EncodeToString(cert.Raw) + `</X509Certificate></X509Data></KeyInfo>` +
+		`</Signature></alert>`)
+	results, err := dsig.Check(data, x509.VerifyOptions{Roots: roots})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Partial reference", "Error: reference #part is not the alert", fmt.Sprint(results[0].Err))
+}
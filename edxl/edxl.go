@@ -0,0 +1,370 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package edxl reads and writes EDXL Distribution Element (EDXL-DE) envelopes,
+in which alerts are routed through IPAWS and other EDXL networks.
+
+Parse reads an EDXL-DE 1.0 or 2.0 envelope, and extracts every CAP alert
+embedded within its content objects. Other content is skipped.
+
+	dist, err := edxl.Parse(data)
+	if err != nil {
+		return err
+	}
+	for _, alert := range dist.Alerts {
+		...
+	}
+
+Wrap places outgoing alerts within a new envelope, and Marshal writes it as an
+EDXL-DE 1.0 document.
+*/
+package edxl
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thetannerryan/cap"
+)
+
+const (
+	// Namespace1 is the namespace of EDXL-DE 1.0 envelopes.
+	Namespace1 = "urn:oasis:names:tc:emergency:EDXL:DE:1.0"
+	// Namespace2 is the namespace of EDXL-DE 2.0 envelopes.
+	Namespace2 = "urn:oasis:names:tc:emergency:EDXL:DE:2.0"
+	// capNamespace is the namespace of embedded CAP 1.2 alerts.
+	capNamespace = "urn:oasis:names:tc:emergency:cap:1.2"
+	// timeFormat is the layout of envelope times.
+	timeFormat = "2006-01-02T15:04:05-07:00"
+)
+
+// Distribution is an EDXL-DE envelope.
+type Distribution struct {
+	Version         string    // "1.0" or "2.0"
+	DistributionID  string    // Unique identifier of the envelope
+	SenderID        string    // Identifier of the sender of the envelope
+	DateTimeSent    time.Time // Time the envelope was sent
+	DateTimeExpires time.Time // Expiry time of the envelope, in 2.0 only
+	Status          string    // Actual, Exercise, System or Test
+	Type            string    // Such as Report, Update or Cancel
+	Confidentiality string    // Combined confidentiality, in 1.0 only
+	TargetAreas     []TargetArea
+	Alerts          []*cap.Alert // Embedded CAP alerts, in document order
+}
+
+// TargetArea is an area to which the envelope is targeted. Circles and polygons
+// are in the CAP "latitude,longitude radius" and "latitude,longitude ..." forms,
+// including those converted from the GML geometry of EDXL-DE 2.0.
+type TargetArea struct {
+	Circle      []string
+	Polygon     []string
+	Country     []string // ISO 3166-1 codes, in 1.0 only
+	Subdivision []string // ISO 3166-2 codes, in 1.0 only
+	LocCodeUN   []string // UN/LOCODE codes, in 1.0 only
+}
+
+// embedded collects the CAP alerts within an embeddedXMLContent element.
+type embedded struct {
+	alerts []*cap.Alert
+}
+
+// UnmarshalXML decodes each CAP alert within the element, skipping other
+// content. Alerts are decoded in place, so that namespaces declared by the
+// envelope are kept.
+func (e *embedded) UnmarshalXML(decoder *xml.Decoder, elem xml.StartElement) error {
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if token.Name.Space != capNamespace || token.Name.Local != "alert" {
+				if err := decoder.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			var alert cap.Alert
+			if err := decoder.DecodeElement(&alert, &token); err != nil {
+				return err
+			}
+			e.alerts = append(e.alerts, &alert)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// xmlTargetArea1 is an EDXL-DE 1.0 targetArea element.
+type xmlTargetArea1 struct {
+	Circle      []string `xml:"circle"`
+	Polygon     []string `xml:"polygon"`
+	Country     []string `xml:"country"`
+	Subdivision []string `xml:"subdivision"`
+	LocCodeUN   []string `xml:"locCodeUN"`
+}
+
+// xmlDistribution1 is an EDXL-DE 1.0 envelope.
+type xmlDistribution1 struct {
+	DistributionID          string           `xml:"distributionID"`
+	SenderID                string           `xml:"senderID"`
+	DateTimeSent            string           `xml:"dateTimeSent"`
+	DistributionStatus      string           `xml:"distributionStatus"`
+	DistributionType        string           `xml:"distributionType"`
+	CombinedConfidentiality string           `xml:"combinedConfidentiality"`
+	TargetArea              []xmlTargetArea1 `xml:"targetArea"`
+	ContentObject           []struct {
+		Embedded []embedded `xml:"xmlContent>embeddedXMLContent"`
+	} `xml:"contentObject"`
+}
+
+// valueKind is an EDXL-DE 2.0 value list element, such as DistributionStatus,
+// holding a Value within a kind element.
+type valueKind struct {
+	Kinds []struct {
+		Value string `xml:"Value"`
+	} `xml:",any"`
+}
+
+// value returns the first Value of the element.
+func (v valueKind) value() string {
+	for _, kind := range v.Kinds {
+		if val := strings.TrimSpace(kind.Value); val != "" {
+			return val
+		}
+	}
+	return ""
+}
+
+// xmlTargetArea2 is an EDXL-DE 2.0 TargetArea element, reduced to the circles
+// and polygons of its GML geometry.
+type xmlTargetArea2 struct {
+	area TargetArea
+}
+
+// UnmarshalXML converts the GML points, circles and polygons within the
+// element. Points become circles of zero radius, and only the exterior ring of
+// each polygon is kept.
+func (t *xmlTargetArea2) UnmarshalXML(decoder *xml.Decoder, elem xml.StartElement) error {
+	var shape, center, radius string
+	var ring []string
+	depth, interior := 0, 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			depth++
+			switch token.Name.Local {
+			case "Polygon", "CircleByCenterPoint", "Point":
+				if shape == "" {
+					shape, center, radius, ring = token.Name.Local, "", "", nil
+				}
+			case "interior":
+				interior++
+			case "pos", "posList":
+				var val string
+				if err := decoder.DecodeElement(&val, &token); err != nil {
+					return err
+				}
+				depth--
+				if interior > 0 {
+					continue
+				}
+				coords := strings.Fields(val)
+				for i := 0; i+1 < len(coords); i += 2 {
+					ring = append(ring, coords[i]+","+coords[i+1])
+				}
+				if len(ring) > 0 {
+					center = ring[0]
+				}
+			case "radius":
+				var val struct {
+					UOM   string `xml:"uom,attr"`
+					Value string `xml:",chardata"`
+				}
+				if err := decoder.DecodeElement(&val, &token); err != nil {
+					return err
+				}
+				depth--
+				radius = gmlRadius(val.UOM, val.Value)
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				return nil
+			}
+			depth--
+			switch token.Name.Local {
+			case "interior":
+				interior--
+			case shape:
+				switch shape {
+				case "Polygon":
+					if len(ring) > 0 {
+						t.area.Polygon = append(t.area.Polygon, strings.Join(ring, " "))
+					}
+				case "CircleByCenterPoint":
+					if center != "" && radius != "" {
+						t.area.Circle = append(t.area.Circle, center+" "+radius)
+					}
+				case "Point":
+					if center != "" {
+						t.area.Circle = append(t.area.Circle, center+" 0")
+					}
+				}
+				shape = ""
+			}
+		}
+	}
+}
+
+// gmlRadius converts a GML radius in the unit of measure to kilometers. If the
+// radius or unit cannot be understood, an empty string is returned.
+func gmlRadius(uom, val string) string {
+	radius, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+	if err != nil {
+		return ""
+	}
+	switch strings.ToLower(uom) {
+	case "km", "urn:ogc:def:uom:epsg::9036":
+	case "m", "urn:ogc:def:uom:epsg::9001":
+		radius /= 1000
+	default:
+		return ""
+	}
+	return strconv.FormatFloat(radius, 'f', -1, 64)
+}
+
+// xmlDistribution2 is an EDXL-DE 2.0 envelope.
+type xmlDistribution2 struct {
+	DistributionID     string           `xml:"DistributionID"`
+	SenderID           string           `xml:"SenderID"`
+	DateTimeSent       string           `xml:"DateTimeSent"`
+	DateTimeExpires    string           `xml:"DateTimeExpires"`
+	DistributionStatus valueKind        `xml:"DistributionStatus"`
+	DistributionKind   valueKind        `xml:"DistributionKind"`
+	TargetArea         []xmlTargetArea2 `xml:"TargetArea"`
+	ContentObject      []struct {
+		Embedded []embedded `xml:"ContentXML>EmbeddedXMLContent"`
+	} `xml:"ContentObject"`
+}
+
+// parseTime parses an envelope time. Absent times are zero.
+func parseTime(elem, val string) (time.Time, error) {
+	val = strings.TrimSpace(val)
+	if val == "" {
+		return time.Time{}, nil
+	}
+	obj, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, errors.New("Error: illegal value " + val + " for " + elem)
+	}
+	return obj, nil
+}
+
+// rootName returns the name of the root element of the document.
+func rootName(data []byte) (xml.Name, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return xml.Name{}, err
+		}
+		if elem, ok := token.(xml.StartElement); ok {
+			return elem.Name, nil
+		}
+	}
+}
+
+// Parse reads an EDXL-DE 1.0 or 2.0 envelope, and extracts its embedded CAP
+// alerts. If the document is not an envelope, or an embedded alert is invalid,
+// an error is returned.
+func Parse(data []byte) (*Distribution, error) {
+	name, err := rootName(data)
+	if err != nil {
+		return nil, err
+	}
+	if name.Local != "EDXLDistribution" {
+		return nil, errors.New("Error: unknown envelope element " + name.Local)
+	}
+	switch name.Space {
+	case Namespace1:
+		return parse1(data)
+	case Namespace2:
+		return parse2(data)
+	}
+	return nil, errors.New("Error: unknown envelope namespace " + name.Space)
+}
+
+// parse1 reads an EDXL-DE 1.0 envelope.
+func parse1(data []byte) (*Distribution, error) {
+	var doc xmlDistribution1
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	sent, err := parseTime("dateTimeSent", doc.DateTimeSent)
+	if err != nil {
+		return nil, err
+	}
+	dist := &Distribution{
+		Version:         "1.0",
+		DistributionID:  strings.TrimSpace(doc.DistributionID),
+		SenderID:        strings.TrimSpace(doc.SenderID),
+		DateTimeSent:    sent,
+		Status:          strings.TrimSpace(doc.DistributionStatus),
+		Type:            strings.TrimSpace(doc.DistributionType),
+		Confidentiality: strings.TrimSpace(doc.CombinedConfidentiality),
+	}
+	for _, area := range doc.TargetArea {
+		dist.TargetAreas = append(dist.TargetAreas, TargetArea(area))
+	}
+	for _, object := range doc.ContentObject {
+		for _, content := range object.Embedded {
+			dist.Alerts = append(dist.Alerts, content.alerts...)
+		}
+	}
+	return dist, nil
+}
+
+// parse2 reads an EDXL-DE 2.0 envelope.
+func parse2(data []byte) (*Distribution, error) {
+	var doc xmlDistribution2
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	sent, err := parseTime("DateTimeSent", doc.DateTimeSent)
+	if err != nil {
+		return nil, err
+	}
+	expires, err := parseTime("DateTimeExpires", doc.DateTimeExpires)
+	if err != nil {
+		return nil, err
+	}
+	dist := &Distribution{
+		Version:         "2.0",
+		DistributionID:  strings.TrimSpace(doc.DistributionID),
+		SenderID:        strings.TrimSpace(doc.SenderID),
+		DateTimeSent:    sent,
+		DateTimeExpires: expires,
+		Status:          doc.DistributionStatus.value(),
+		Type:            doc.DistributionKind.value(),
+	}
+	for _, area := range doc.TargetArea {
+		dist.TargetAreas = append(dist.TargetAreas, area.area)
+	}
+	for _, object := range doc.ContentObject {
+		for _, content := range object.Embedded {
+			dist.Alerts = append(dist.Alerts, content.alerts...)
+		}
+	}
+	return dist, nil
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edxl_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/thetannerryan/cap"
+	"github.com/thetannerryan/cap/edxl"
+)
+
+// test is a helper for the tests.
+func test(t *testing.T, name, expected, actual string) {
+	fmt.Printf(">> Testing %s\nExpected: %s\nActual:   %s\n", name, expected, actual)
+	if expected != actual {
+		t.Errorf("Incorrect output")
+	}
+}
+
+// fixture returns one of the example alerts.
+func fixture(name string) []byte {
+	contents, err := ioutil.ReadFile("../testing/" + name)
+	if err != nil {
+		panic(err)
+	}
+	return contents
+}
+
+// prolog matches the XML declaration and comments preceding the alert element
+// of a fixture.
+var prolog = regexp.MustCompile(`(?s)^.*?(<alert)`)
+
+// embed returns a fixture without its prolog, for embedding in an envelope.
+func embed(name string) string {
+	return prolog.ReplaceAllString(string(fixture(name)), "$1")
+}
+
+// envelope1 is an EDXL-DE 1.0 envelope in the style of IPAWS, carrying two
+// alerts.
+func envelope1() string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<EDXLDistribution xmlns="urn:oasis:names:tc:emergency:EDXL:DE:1.0">
+<distributionID>DE-1055887203</distributionID>
+<senderID>ipaws@example.gov</senderID>
+<dateTimeSent>2003-06-17T14:57:10-07:00</dateTimeSent>
+<distributionStatus>Actual</distributionStatus>
+<distributionType>Report</distributionType>
+<combinedConfidentiality>UNCLASSIFIED AND NOT SENSITIVE</combinedConfidentiality>
+<targetArea>
+<polygon>38.47,-120.14 38.34,-119.95 38.52,-119.74 38.62,-119.89 38.47,-120.14</polygon>
+<country>US</country>
+<subdivision>US-CA</subdivision>
+</targetArea>
+<contentObject>
+<contentDescription>Severe thunderstorm warning</contentDescription>
+<xmlContent><embeddedXMLContent>` + embed("Oasis_ThunderstormWarning.xml") + `</embeddedXMLContent></xmlContent>
+</contentObject>
+<contentObject>
+<nonXMLContent><mimeType>text/plain</mimeType></nonXMLContent>
+</contentObject>
+<contentObject>
+<xmlContent><embeddedXMLContent>` + embed("Oasis_EarthquakeReport.xml") + `</embeddedXMLContent></xmlContent>
+</contentObject>
+</EDXLDistribution>`
+}
+
+// envelope2 is an EDXL-DE 2.0 envelope with GML target areas.
+var envelope2 = `<?xml version="1.0" encoding="UTF-8"?>
+<EDXLDistribution xmlns="urn:oasis:names:tc:emergency:EDXL:DE:2.0"
+  xmlns:ct="urn:oasis:names:tc:emergency:edxl:ct:1.0"
+  xmlns:gml="http://www.opengis.net/gml/3.2"
+  xmlns:cap="urn:oasis:names:tc:emergency:cap:1.2">
+<DistributionID>DE2-42</DistributionID>
+<SenderID>sender@example.org</SenderID>
+<DateTimeSent>2019-01-09T02:17:00+00:00</DateTimeSent>
+<DateTimeExpires>2019-01-10T02:17:00+00:00</DateTimeExpires>
+<DistributionStatus><StatusKindDefault><ValueListURI>urn:oasis:names:tc:emergency:edxl:de:2.0:DistributionStatusDefaultValues</ValueListURI><Value>Exercise</Value></StatusKindDefault></DistributionStatus>
+<DistributionKind><DistributionKindDefault><ValueListURI>urn:oasis:names:tc:emergency:edxl:de:2.0:DistributionKindDefaultValues</ValueListURI><Value>Update</Value></DistributionKindDefault></DistributionKind>
+<TargetArea><ct:EDXLGeoLocation><ct:GeoLocation>
+<gml:Polygon><gml:exterior><gml:LinearRing><gml:posList>47.1 -61.7 47.2 -62.1 47.5 -62.0 47.1 -61.7</gml:posList></gml:LinearRing></gml:exterior>
+<gml:interior><gml:LinearRing><gml:posList>47.2 -61.9 47.3 -61.9 47.3 -61.8 47.2 -61.9</gml:posList></gml:LinearRing></gml:interior></gml:Polygon>
+</ct:GeoLocation></ct:EDXLGeoLocation></TargetArea>
+<TargetArea><ct:EDXLGeoLocation><ct:GeoLocation>
+<gml:CircleByCenterPoint numArc="1"><gml:pos>32.9525 -115.5527</gml:pos><gml:radius uom="m">2500</gml:radius></gml:CircleByCenterPoint>
+</ct:GeoLocation></ct:EDXLGeoLocation></TargetArea>
+<ContentObject><ContentXML><EmbeddedXMLContent>
+<cap:alert>
+<cap:identifier>KSTO1055887203</cap:identifier>
+<cap:sender>KSTO@NWS.NOAA.GOV</cap:sender>
+<cap:sent>2003-06-17T14:57:00-07:00</cap:sent>
+<cap:status>Exercise</cap:status>
+<cap:msgType>Alert</cap:msgType>
+<cap:scope>Public</cap:scope>
+</cap:alert>
+</EmbeddedXMLContent></ContentXML></ContentObject>
+</EDXLDistribution>`
+
+// TestParse1 tests unwrapping an EDXL-DE 1.0 envelope.
+func TestParse1(t *testing.T) {
+	dist, err := edxl.Parse([]byte(envelope1()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Version", "1.0", dist.Version)
+	test(t, "Distribution ID", "DE-1055887203", dist.DistributionID)
+	test(t, "Sender ID", "ipaws@example.gov", dist.SenderID)
+	test(t, "Sent", "2003-06-17 21:57:10 +0000 UTC", dist.DateTimeSent.UTC().String())
+	test(t, "Status", "Actual", dist.Status)
+	test(t, "Type", "Report", dist.Type)
+	test(t, "Confidentiality", "UNCLASSIFIED AND NOT SENSITIVE", dist.Confidentiality)
+	test(t, "Target areas", "1", fmt.Sprint(len(dist.TargetAreas)))
+	test(t, "Target subdivision", "[US-CA]", fmt.Sprint(dist.TargetAreas[0].Subdivision))
+	test(t, "Alerts", "2", fmt.Sprint(len(dist.Alerts)))
+	test(t, "First alert", "KSTO1055887203", dist.Alerts[0].Identifier)
+	test(t, "First alert event", "SEVERE THUNDERSTORM", dist.Alerts[0].Info[0].Event)
+	test(t, "Second alert", "TRI13970876.2", dist.Alerts[1].Identifier)
+}
+
+// TestParse2 tests unwrapping an EDXL-DE 2.0 envelope, with a prefixed CAP
+// namespace declared by the envelope.
+func TestParse2(t *testing.T) {
+	dist, err := edxl.Parse([]byte(envelope2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Version", "2.0", dist.Version)
+	test(t, "Distribution ID", "DE2-42", dist.DistributionID)
+	test(t, "Expires", "2019-01-10 02:17:00 +0000 UTC", dist.DateTimeExpires.String())
+	test(t, "Status", "Exercise", dist.Status)
+	test(t, "Type", "Update", dist.Type)
+	test(t, "Target areas", "2", fmt.Sprint(len(dist.TargetAreas)))
+	test(t, "Polygon", "[47.1,-61.7 47.2,-62.1 47.5,-62.0 47.1,-61.7]", fmt.Sprint(dist.TargetAreas[0].Polygon))
+	test(t, "Circle", "[32.9525,-115.5527 2.5]", fmt.Sprint(dist.TargetAreas[1].Circle))
+	test(t, "Alerts", "1", fmt.Sprint(len(dist.Alerts)))
+	test(t, "Alert status", "Exercise", dist.Alerts[0].Status.String())
+}
+
+// TestParseErrors tests rejecting documents that are not envelopes.
+func TestParseErrors(t *testing.T) {
+	_, err := edxl.Parse(fixture("Oasis_ThunderstormWarning.xml"))
+	test(t, "Bare alert", "Error: unknown envelope element alert", fmt.Sprint(err))
+	_, err = edxl.Parse([]byte(`<EDXLDistribution xmlns="urn:example"/>`))
+	test(t, "Unknown namespace", "Error: unknown envelope namespace urn:example", fmt.Sprint(err))
+}
+
+// TestWrap tests wrapping alerts, and unwrapping the written envelope.
+func TestWrap(t *testing.T) {
+	alert, err := cap.ParseCAP(fixture("Oasis_ThunderstormWarning.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent := time.Date(2003, 6, 17, 21, 58, 0, 0, time.UTC)
+	dist := edxl.Wrap("cap@example.org", cap.FixedClock(sent), alert)
+	test(t, "Wrap type", "Report", dist.Type)
+	test(t, "Wrap sent", sent.String(), dist.DateTimeSent.String())
+	test(t, "Wrap polygon", "[38.47,-120.14 38.34,-119.95 38.52,-119.74 38.62,-119.89 38.47,-120.14]", fmt.Sprint(dist.TargetAreas[0].Polygon))
+
+	data, err := edxl.Marshal(dist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := edxl.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Round trip ID", dist.DistributionID, parsed.DistributionID)
+	test(t, "Round trip sender", "cap@example.org", parsed.SenderID)
+	test(t, "Round trip sent", sent.String(), parsed.DateTimeSent.UTC().String())
+	test(t, "Round trip target areas", "1", fmt.Sprint(len(parsed.TargetAreas)))
+	test(t, "Round trip alerts", "1", fmt.Sprint(len(parsed.Alerts)))
+	test(t, "Round trip alert", alert.Reference(), parsed.Alerts[0].Reference())
+	test(t, "Round trip headline", alert.Info[0].Headline, parsed.Alerts[0].Info[0].Headline)
+
+	dist.SenderID = ""
+	_, err = edxl.Marshal(dist)
+	test(t, "Missing sender", "Error: missing senderID in envelope", fmt.Sprint(err))
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edxl
+
+import (
+	"encoding/xml"
+	"errors"
+	"time"
+
+	"github.com/thetannerryan/cap"
+)
+
+// distributionTypes maps each CAP MsgType to the EDXL-DE distributionType.
+var distributionTypes = map[cap.MsgType]string{
+	cap.MsgTypeAlert:  "Report",
+	cap.MsgTypeUpdate: "Update",
+	cap.MsgTypeCancel: "Cancel",
+	cap.MsgTypeAck:    "Ack",
+	cap.MsgTypeError:  "Error",
+}
+
+// distributionStatus returns the EDXL-DE distributionStatus of the CAP Status
+// of the alert. EDXL-DE has no Draft status, so drafts are sent as tests.
+func distributionStatus(alert *cap.Alert) string {
+	if alert.Status == cap.StatusDraft {
+		return "Test"
+	}
+	return alert.CodeText(alert.Status)
+}
+
+// Wrap returns an envelope from the sender carrying the alerts. It has a new
+// distribution ID and is sent at the current time of the clock. Its status and
+// type follow the first alert, and its target areas are the polygons and
+// circles of every alert. If the clock is nil, the cap.SystemClock is used.
+func Wrap(senderID string, clock cap.Clock, alerts ...*cap.Alert) *Distribution {
+	if clock == nil {
+		clock = cap.SystemClock
+	}
+	dist := &Distribution{
+		Version:         "1.0",
+		DistributionID:  cap.UUIDGenerator{}.NewIdentifier(),
+		SenderID:        senderID,
+		DateTimeSent:    clock.Now().Truncate(time.Second),
+		Status:          "Actual",
+		Type:            "Report",
+		Confidentiality: "UNCLASSIFIED AND NOT SENSITIVE",
+		Alerts:          alerts,
+	}
+	if len(alerts) > 0 {
+		dist.Status = distributionStatus(alerts[0])
+		dist.Type = distributionTypes[alerts[0].MsgType]
+	}
+	for _, alert := range alerts {
+		for _, info := range alert.Info {
+			for _, area := range info.Area {
+				var target TargetArea
+				if polygon := area.Polygon.String(); polygon != "" {
+					target.Polygon = append(target.Polygon, polygon)
+				}
+				for _, circle := range area.Circle {
+					if circle != "" {
+						target.Circle = append(target.Circle, circle)
+					}
+				}
+				if len(target.Polygon) > 0 || len(target.Circle) > 0 {
+					dist.TargetAreas = append(dist.TargetAreas, target)
+				}
+			}
+		}
+	}
+	return dist
+}
+
+// xmlOutContent is an EDXL-DE 1.0 contentObject carrying a CAP alert.
+type xmlOutContent struct {
+	Alert *cap.Alert `xml:"xmlContent>embeddedXMLContent>alert"`
+}
+
+// xmlOutDistribution is an EDXL-DE 1.0 envelope being written.
+type xmlOutDistribution struct {
+	XMLName                 xml.Name         `xml:"urn:oasis:names:tc:emergency:EDXL:DE:1.0 EDXLDistribution"`
+	DistributionID          string           `xml:"distributionID"`
+	SenderID                string           `xml:"senderID"`
+	DateTimeSent            string           `xml:"dateTimeSent"`
+	DistributionStatus      string           `xml:"distributionStatus"`
+	DistributionType        string           `xml:"distributionType"`
+	CombinedConfidentiality string           `xml:"combinedConfidentiality"`
+	TargetArea              []xmlTargetArea1 `xml:"targetArea"`
+	ContentObject           []xmlOutContent  `xml:"contentObject"`
+}
+
+// Marshal writes the envelope as an EDXL-DE 1.0 document, whatever its Version.
+// The distribution ID, sender ID, status, type and confidentiality are required
+// by EDXL-DE 1.0, and an error is returned if any are missing.
+func Marshal(dist *Distribution) ([]byte, error) {
+	doc := xmlOutDistribution{
+		DistributionID:          dist.DistributionID,
+		SenderID:                dist.SenderID,
+		DateTimeSent:            dist.DateTimeSent.Format(timeFormat),
+		DistributionStatus:      dist.Status,
+		DistributionType:        dist.Type,
+		CombinedConfidentiality: dist.Confidentiality,
+	}
+	required := []struct{ elem, val string }{
+		{"distributionID", doc.DistributionID},
+		{"senderID", doc.SenderID},
+		{"distributionStatus", doc.DistributionStatus},
+		{"distributionType", doc.DistributionType},
+		{"combinedConfidentiality", doc.CombinedConfidentiality},
+	}
+	for _, field := range required {
+		if field.val == "" {
+			return nil, errors.New("Error: missing " + field.elem + " in envelope")
+		}
+	}
+	if dist.DateTimeSent.IsZero() {
+		return nil, errors.New("Error: missing dateTimeSent in envelope")
+	}
+	for _, area := range dist.TargetAreas {
+		doc.TargetArea = append(doc.TargetArea, xmlTargetArea1(area))
+	}
+	for _, alert := range dist.Alerts {
+		doc.ContentObject = append(doc.ContentObject, xmlOutContent{Alert: alert})
+	}
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Sentinel errors, which may be tested for with errors.Is.
+var (
+	// ErrIllegalEnumValue is wrapped by errors for code elements whose text is
+	// not one of the values defined by CAP 1.2.
+	ErrIllegalEnumValue = errors.New("Error: illegal enum value")
+	// ErrBadDateTime is wrapped by errors for dateTime elements that are not
+	// formatted correctly.
+	ErrBadDateTime = errors.New("Error: bad dateTime value")
+	// ErrBadIdentifier is wrapped by errors for identifier and sender values
+	// that are empty or contain characters forbidden by CAP 1.2.
+	ErrBadIdentifier = errors.New("Error: bad identifier value")
+)
+
+// ParseError describes a CAP message that could not be parsed, and where the
+// problem lies within it.
+type ParseError struct {
+	Path   string // Slash separated element path, such as alert/info[2]/urgency
+	Line   int    // Line of the offending element, starting at 1
+	Column int    // Byte column of the offending element, starting at 1
+	Value  string // Text of the offending element, if known
+	Err    error  // Underlying cause
+}
+
+// Error returns the cause of the ParseError and its location.
+func (e *ParseError) Error() string {
+	return e.Err.Error() + " at " + e.Path + " (line " + strconv.Itoa(e.Line) +
+		", column " + strconv.Itoa(e.Column) + ")"
+}
+
+// Unwrap returns the underlying cause of the ParseError.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// valueError is an error for the text of a single element. It records the
+// input offset just past the start tag of the element, so that the element
+// may be located once decoding has failed.
+type valueError struct {
+	msg    string
+	value  string
+	kind   error
+	offset int64
+}
+
+// Error returns the message of the valueError.
+func (e *valueError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the sentinel error of the valueError.
+func (e *valueError) Unwrap() error {
+	return e.kind
+}
+
+// illegalValue returns an error for text that is not one of the values of the
+// named code.
+func illegalValue(val, code string) error {
+	return &valueError{
+		msg:    "Error: illegal value " + val + " for " + code + " code",
+		value:  val,
+		kind:   ErrIllegalEnumValue,
+		offset: -1,
+	}
+}
+
+// atOffset records the input offset of the element on a valueError. Other
+// errors are returned unchanged.
+func atOffset(err error, offset int64) error {
+	if e, ok := err.(*valueError); ok {
+		e.offset = offset
+	}
+	return err
+}
+
+// repeated is the set of CAP elements that may occur more than once within
+// their parent. Their paths always carry a position index.
+var repeated = map[string]bool{
+	"code":         true,
+	"info":         true,
+	"category":     true,
+	"responseType": true,
+	"eventCode":    true,
+	"parameter":    true,
+	"resource":     true,
+	"area":         true,
+	"polygon":      true,
+	"circle":       true,
+	"geocode":      true,
+	"Signature":    true,
+}
+
+// location is the element path, line and column of an input offset.
+type location struct {
+	path   string
+	line   int
+	column int
+}
+
+// locate scans the message up to the input offset and returns the location
+// of the element containing it. If exact is set, the offset must be that just
+// past a start tag, and the location is of that start tag.
+func locate(data []byte, offset int64, exact bool) location {
+	type frame struct {
+		name   string
+		counts map[string]int
+	}
+	stack := []frame{{counts: map[string]int{}}}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	start := int64(0)
+	for {
+		before := decoder.InputOffset()
+		tok, err := decoder.RawToken()
+		after := decoder.InputOffset()
+		if err != nil || (!exact && after >= offset) {
+			start = before
+			break
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			parent := stack[len(stack)-1]
+			parent.counts[tok.Name.Local]++
+			name := tok.Name.Local
+			if repeated[name] {
+				name += "[" + strconv.Itoa(parent.counts[tok.Name.Local]) + "]"
+			}
+			stack = append(stack, frame{name: name, counts: map[string]int{}})
+			if exact && after == offset {
+				start = before
+			}
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+		if exact && after >= offset {
+			break
+		}
+		start = after
+	}
+
+	path := make([]string, len(stack)-1)
+	for i, frame := range stack[1:] {
+		path[i] = frame.name
+	}
+	return position(data, start, path)
+}
+
+// position returns the location of a byte offset with the given path.
+func position(data []byte, offset int64, path []string) location {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	prefix := data[:offset]
+	line := bytes.Count(prefix, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(prefix, '\n')
+	return location{path: strings.Join(path, "/"), line: line, column: column}
+}
+
+// parseError wraps an error returned while decoding the message in a
+// ParseError. The decoder is used to locate errors that do not carry their
+// own offset.
+func parseError(data []byte, decoder *xml.Decoder, err error) error {
+	var loc location
+	var value string
+	if e, ok := err.(*valueError); ok && e.offset >= 0 {
+		loc = locate(data, e.offset, true)
+		value = e.value
+	} else {
+		loc = locate(data, decoder.InputOffset(), false)
+	}
+	return &ParseError{
+		Path:   loc.path,
+		Line:   loc.line,
+		Column: loc.column,
+		Value:  value,
+		Err:    err,
+	}
+}
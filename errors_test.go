@@ -0,0 +1,45 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/thetannerryan/cap"
+)
+
+// TestParseError tests the path, position and cause of parse errors.
+func TestParseError(t *testing.T) {
+	_, err := cap.ParseCAP([]byte(`<alert xmlns="urn:oasis:names:tc:emergency:cap:1.2">
+	<sent>2003-06-17T14:57:00-07:00</sent>
+	<info><urgency>Immediate</urgency></info>
+	<info>
+		<urgency>Soon</urgency>
+	</info>
+</alert>`))
+	var parseErr *cap.ParseError
+	test(t, "Enum error as", "true", strconv.FormatBool(errors.As(err, &parseErr)))
+	test(t, "Enum error is", "true", strconv.FormatBool(errors.Is(err, cap.ErrIllegalEnumValue)))
+	test(t, "Enum error is not", "false", strconv.FormatBool(errors.Is(err, cap.ErrBadDateTime)))
+	test(t, "Enum error path", "alert/info[2]/urgency", parseErr.Path)
+	test(t, "Enum error position", "5:3", fmt.Sprintf("%d:%d", parseErr.Line, parseErr.Column))
+	test(t, "Enum error value", "Soon", parseErr.Value)
+
+	_, err = cap.ParseCAP([]byte(`<alert xmlns="urn:oasis:names:tc:emergency:cap:1.2"><info><area/></info><info><expires>tomorrow</expires></info></alert>`))
+	test(t, "DateTime error is", "true", strconv.FormatBool(errors.Is(err, cap.ErrBadDateTime)))
+	test(t, "DateTime error", "Error: illegal value tomorrow for DateTime at alert/info[2]/expires (line 1, column 79)", fmt.Sprint(err))
+
+	_, err = cap.ParseCAP([]byte(`<alert xmlns="urn:oasis:names:tc:emergency:cap:1.2"><info><resource><size>big</size></resource></info></alert>`))
+	test(t, "Size error as", "true", strconv.FormatBool(errors.As(err, &parseErr)))
+	test(t, "Size error path", "alert/info[1]/resource[1]/size", parseErr.Path)
+
+	_, err = cap.ParseCAP([]byte("<alert xmlns=\"urn:oasis:names:tc:emergency:cap:1.2\">\n<info>\n<event>x</evnt>"))
+	test(t, "Syntax error as", "true", strconv.FormatBool(errors.As(err, &parseErr)))
+	test(t, "Syntax error path", "alert/info[1]/event", parseErr.Path)
+	test(t, "Syntax error line", "3", strconv.Itoa(parseErr.Line))
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filter
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/thetannerryan/cap"
+)
+
+// context is the Alert and Info that an expression is evaluated against. The
+// Info is nil for Alerts without any Info.
+type context struct {
+	alert *cap.Alert
+	info  *cap.Info
+}
+
+// field describes a filterable element of an Alert, Info or Area. Enumerated
+// fields provide enums, while all other fields provide texts.
+type field struct {
+	name     string
+	keyed    bool
+	language bool
+	enum     map[string]int
+	names    map[int]string
+	text     func(c *context, val int) string
+	rank     func(int) int
+	enums    func(c *context) []int
+	texts    func(c *context, key string) []string
+}
+
+// supports returns an error if the operator cannot be applied to the field.
+func (f *field) supports(op string) error {
+	switch op {
+	case "==", "!=", "in":
+		return nil
+	case "contains":
+		if f.enum == nil {
+			return nil
+		}
+	case "<", "<=", ">", ">=":
+		if f.rank != nil {
+			return nil
+		}
+	}
+	return errors.New("operator " + op + " not supported for field " + f.name)
+}
+
+// fields maps lowercase field names to their definitions.
+var fields = map[string]*field{}
+
+// addField registers a field under its lowercase name.
+func addField(f *field) {
+	fields[strings.ToLower(f.name)] = f
+}
+
+// enumField returns an enumerated field given one of the cap code mappings,
+// and the accessor of the element text of its codes.
+func enumField(name string, mapping interface{}, text func(c *context, val int) string, enums func(c *context) []int) *field {
+	f := &field{
+		name:  name,
+		enum:  make(map[string]int),
+		names: make(map[int]string),
+		text:  text,
+		enums: enums,
+	}
+	iter := reflect.ValueOf(mapping).MapRange()
+	for iter.Next() {
+		key, val := iter.Key().String(), int(iter.Value().Int())
+		f.enum[strings.ToLower(key)] = val
+		f.names[val] = key
+	}
+	return f
+}
+
+// alertCode returns the accessor of the element text of an Alert code, which
+// is the original text of codes kept by lenient parsing.
+func alertCode(code func(val int) cap.Code) func(c *context, val int) string {
+	return func(c *context, val int) string {
+		return c.alert.CodeText(code(val))
+	}
+}
+
+// infoCode returns the accessor of the element text of an Info code, which is
+// the original text of codes kept by lenient parsing.
+func infoCode(code func(val int) cap.Code) func(c *context, val int) string {
+	return func(c *context, val int) string {
+		return c.info.CodeText(code(val))
+	}
+}
+
+// alertText returns a text accessor for a single Alert element.
+func alertText(get func(a *cap.Alert) string) func(c *context, key string) []string {
+	return func(c *context, key string) []string {
+		return []string{get(c.alert)}
+	}
+}
+
+// infoText returns a text accessor for a single Info element.
+func infoText(get func(i *cap.Info) string) func(c *context, key string) []string {
+	return func(c *context, key string) []string {
+		if c.info == nil {
+			return nil
+		}
+		return []string{get(c.info)}
+	}
+}
+
+// keyedValues returns the values of the KeyValue pairs matching the key.
+func keyedValues(pairs []cap.KeyValue, key string, vals []string) []string {
+	for _, pair := range pairs {
+		if strings.EqualFold(pair.ValueName, key) {
+			vals = append(vals, pair.Value)
+		}
+	}
+	return vals
+}
+
+// severityRank orders Severity codes by their Rank.
+func severityRank(v int) int {
+	return cap.Severity(v).Rank()
+}
+
+// urgencyRank orders Urgency codes by their Rank.
+func urgencyRank(v int) int {
+	return cap.Urgency(v).Rank()
+}
+
+// certaintyRank orders Certainty codes by their Rank.
+func certaintyRank(v int) int {
+	return cap.Certainty(v).Rank()
+}
+
+func init() {
+	// Alert elements
+	addField(&field{name: "identifier", texts: alertText(func(a *cap.Alert) string { return a.Identifier })})
+	addField(&field{name: "sender", texts: alertText(func(a *cap.Alert) string { return a.Sender })})
+	addField(&field{name: "source", texts: alertText(func(a *cap.Alert) string { return a.Source })})
+	addField(&field{name: "note", texts: alertText(func(a *cap.Alert) string { return a.Note })})
+	addField(&field{name: "incidents", texts: func(c *context, key string) []string { return c.alert.Incidents.Values() }})
+	addField(&field{name: "code", texts: func(c *context, key string) []string { return c.alert.Code }})
+	addField(enumField("status", cap.StatusMapping, alertCode(func(v int) cap.Code { return cap.Status(v) }), func(c *context) []int {
+		return []int{int(c.alert.Status)}
+	}))
+	addField(enumField("msgType", cap.MsgTypeMapping, alertCode(func(v int) cap.Code { return cap.MsgType(v) }), func(c *context) []int {
+		return []int{int(c.alert.MsgType)}
+	}))
+	addField(enumField("scope", cap.ScopeMapping, alertCode(func(v int) cap.Code { return cap.Scope(v) }), func(c *context) []int {
+		return []int{int(c.alert.Scope)}
+	}))
+
+	// Info elements
+	addField(&field{name: "language", language: true, texts: infoText(func(i *cap.Info) string { return i.Language })})
+	addField(&field{name: "event", texts: infoText(func(i *cap.Info) string { return i.Event })})
+	addField(&field{name: "audience", texts: infoText(func(i *cap.Info) string { return i.Audience })})
+	addField(&field{name: "senderName", texts: infoText(func(i *cap.Info) string { return i.SenderName })})
+	addField(&field{name: "headline", texts: infoText(func(i *cap.Info) string { return i.Headline })})
+	addField(&field{name: "description", texts: infoText(func(i *cap.Info) string { return i.Description })})
+	addField(&field{name: "instruction", texts: infoText(func(i *cap.Info) string { return i.Instruction })})
+	addField(&field{name: "eventCode", keyed: true, texts: func(c *context, key string) []string {
+		if c.info == nil {
+			return nil
+		}
+		return keyedValues(c.info.EventCode, key, nil)
+	}})
+	addField(&field{name: "parameter", keyed: true, texts: func(c *context, key string) []string {
+		if c.info == nil {
+			return nil
+		}
+		return keyedValues(c.info.Parameter, key, nil)
+	}})
+	addField(enumField("category", cap.CategoryMapping, infoCode(func(v int) cap.Code { return cap.Category(v) }), func(c *context) []int {
+		if c.info == nil {
+			return nil
+		}
+		vals := make([]int, len(c.info.Category))
+		for i, val := range c.info.Category {
+			vals[i] = int(val)
+		}
+		return vals
+	}))
+	addField(enumField("responseType", cap.ResponseTypeMapping, infoCode(func(v int) cap.Code { return cap.ResponseType(v) }), func(c *context) []int {
+		if c.info == nil {
+			return nil
+		}
+		vals := make([]int, len(c.info.ResponseType))
+		for i, val := range c.info.ResponseType {
+			vals[i] = int(val)
+		}
+		return vals
+	}))
+	urgency := enumField("urgency", cap.UrgencyMapping, infoCode(func(v int) cap.Code { return cap.Urgency(v) }), func(c *context) []int {
+		if c.info == nil {
+			return nil
+		}
+		return []int{int(c.info.Urgency)}
+	})
+	urgency.rank = urgencyRank
+	addField(urgency)
+	severity := enumField("severity", cap.SeverityMapping, infoCode(func(v int) cap.Code { return cap.Severity(v) }), func(c *context) []int {
+		if c.info == nil {
+			return nil
+		}
+		return []int{int(c.info.Severity)}
+	})
+	severity.rank = severityRank
+	addField(severity)
+	certainty := enumField("certainty", cap.CertaintyMapping, infoCode(func(v int) cap.Code { return cap.Certainty(v) }), func(c *context) []int {
+		if c.info == nil {
+			return nil
+		}
+		return []int{int(c.info.Certainty)}
+	})
+	certainty.rank = certaintyRank
+	addField(certainty)
+
+	// Area elements, matched against every Area of the Info
+	addField(&field{name: "areaDesc", texts: func(c *context, key string) []string {
+		if c.info == nil {
+			return nil
+		}
+		var vals []string
+		for _, area := range c.info.Area {
+			vals = append(vals, area.AreaDesc)
+		}
+		return vals
+	}})
+	addField(&field{name: "geocode", keyed: true, texts: func(c *context, key string) []string {
+		if c.info == nil {
+			return nil
+		}
+		var vals []string
+		for _, area := range c.info.Area {
+			vals = keyedValues(area.Geocode, key, vals)
+		}
+		return vals
+	}})
+}
@@ -0,0 +1,321 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package filter compiles a small expression language for routing CAP alerts.
+
+Expressions compare Alert, Info and Area fields against values, and are
+combined with "and", "or", "not" and parentheses.
+
+    severity >= Severe and category in (Met, Fire) and status == Actual
+    eventCode SAME in (TOR, SVR)
+    language == fr and not geocode "profile:CAP-CP:Location:0.3" == 2401
+
+The operators "==", "!=", "in" and "contains" are supported for every field.
+The ordering operators "<", "<=", ">" and ">=" are supported for urgency,
+severity and certainty, where Unknown ranks lowest. Field names, keywords and
+values are matched without regard to case. The keyed fields eventCode,
+parameter and geocode take a valueName before the operator. A language value
+without a subtag, such as "en", matches every "en-XX" Info.
+
+Info and Area fields are evaluated against each Info of the Alert in turn, and
+an Alert matches if any single Info satisfies the expression. Fields with
+multiple values, such as category, match if any value does; "!=" is the
+negation of "==".
+*/
+package filter
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/thetannerryan/cap"
+)
+
+// Filter is a compiled expression. A Filter is safe for concurrent use.
+type Filter struct {
+	expr string
+	root node
+}
+
+// Explanation describes the result of evaluating a Filter against an Alert.
+type Explanation struct {
+	Matched bool     // Whether the Alert matched
+	Info    int      // Index of the first matching Info, or -1
+	Reasons []string // Comparisons that determined the result
+}
+
+// Compile parses an expression into a Filter. If the expression is invalid, a
+// *SyntaxError will be returned.
+func Compile(expr string) (*Filter, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, &SyntaxError{tok.pos, "unexpected " + tok.describe()}
+	}
+	return &Filter{expr: expr, root: root}, nil
+}
+
+// MustCompile is like Compile, but panics if the expression is invalid.
+func MustCompile(expr string) *Filter {
+	f, err := Compile(expr)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// String returns the source expression of the Filter.
+func (f *Filter) String() string {
+	return f.expr
+}
+
+// Match reports whether the Alert satisfies the Filter.
+func (f *Filter) Match(alert *cap.Alert) bool {
+	if len(alert.Info) == 0 {
+		matched, _ := f.root.eval(&context{alert: alert}, false)
+		return matched
+	}
+	for i := range alert.Info {
+		if matched, _ := f.root.eval(&context{alert: alert, info: &alert.Info[i]}, false); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Explain evaluates the Filter against the Alert and reports the comparisons
+// that determined the result. For a match these are the comparisons of the
+// first matching Info; otherwise, those of every Info are included.
+func (f *Filter) Explain(alert *cap.Alert) Explanation {
+	if len(alert.Info) == 0 {
+		matched, reasons := f.root.eval(&context{alert: alert}, true)
+		return Explanation{Matched: matched, Info: -1, Reasons: reasons}
+	}
+	var all []string
+	for i := range alert.Info {
+		matched, reasons := f.root.eval(&context{alert: alert, info: &alert.Info[i]}, true)
+		if matched {
+			return Explanation{Matched: true, Info: i, Reasons: reasons}
+		}
+		all = append(all, reasons...)
+	}
+	return Explanation{Matched: false, Info: -1, Reasons: all}
+}
+
+// node is a compiled expression. When trace is set, eval also returns the
+// comparisons that determined its result.
+type node interface {
+	eval(c *context, trace bool) (bool, []string)
+}
+
+// andNode is the conjunction of two expressions.
+type andNode struct {
+	left, right node
+}
+
+func (n *andNode) eval(c *context, trace bool) (bool, []string) {
+	left, leftReasons := n.left.eval(c, trace)
+	if !left {
+		return false, leftReasons
+	}
+	right, rightReasons := n.right.eval(c, trace)
+	if !right {
+		return false, rightReasons
+	}
+	return true, append(leftReasons, rightReasons...)
+}
+
+// orNode is the disjunction of two expressions.
+type orNode struct {
+	left, right node
+}
+
+func (n *orNode) eval(c *context, trace bool) (bool, []string) {
+	left, leftReasons := n.left.eval(c, trace)
+	if left {
+		return true, leftReasons
+	}
+	right, rightReasons := n.right.eval(c, trace)
+	if right {
+		return true, rightReasons
+	}
+	return false, append(leftReasons, rightReasons...)
+}
+
+// notNode is the negation of an expression.
+type notNode struct {
+	inner node
+}
+
+func (n *notNode) eval(c *context, trace bool) (bool, []string) {
+	matched, reasons := n.inner.eval(c, trace)
+	for i := range reasons {
+		reasons[i] = "not " + reasons[i]
+	}
+	return !matched, reasons
+}
+
+// comparison is a single field compared against one or more values. Enumerated
+// values are resolved to codes when compiled; text values are kept as given.
+type comparison struct {
+	field *field
+	key   string
+	op    string
+	enums []int
+	texts []string
+}
+
+// addValue resolves and appends a value to the comparison.
+func (n *comparison) addValue(val string) error {
+	if n.field.enum == nil {
+		n.texts = append(n.texts, val)
+		return nil
+	}
+	code, ok := n.field.enum[strings.ToLower(val)]
+	if !ok {
+		return errors.New("illegal value " + val + " for " + n.field.name)
+	}
+	n.enums = append(n.enums, code)
+	return nil
+}
+
+func (n *comparison) eval(c *context, trace bool) (bool, []string) {
+	var matched bool
+	var got []string
+	if n.field.enum != nil {
+		vals := n.field.enums(c)
+		matched = n.matchEnums(vals)
+		if trace {
+			for _, val := range vals {
+				got = append(got, n.field.text(c, val))
+			}
+		}
+	} else {
+		got = n.field.texts(c, n.key)
+		matched = n.matchTexts(got)
+	}
+	if !trace {
+		return matched, nil
+	}
+	return matched, []string{n.String() + " (got " + describe(got) + ")"}
+}
+
+// matchEnums reports whether any code satisfies the comparison.
+func (n *comparison) matchEnums(vals []int) bool {
+	if n.op == "!=" {
+		return !n.matchEnumsEqual(vals)
+	}
+	if n.op == "==" || n.op == "in" {
+		return n.matchEnumsEqual(vals)
+	}
+	want := n.field.rank(n.enums[0])
+	for _, val := range vals {
+		have := n.field.rank(val)
+		switch {
+		case n.op == "<" && have < want,
+			n.op == "<=" && have <= want,
+			n.op == ">" && have > want,
+			n.op == ">=" && have >= want:
+			return true
+		}
+	}
+	return false
+}
+
+// matchEnumsEqual reports whether any code is one of the comparison values.
+func (n *comparison) matchEnumsEqual(vals []int) bool {
+	for _, val := range vals {
+		for _, want := range n.enums {
+			if val == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchTexts reports whether any text satisfies the comparison.
+func (n *comparison) matchTexts(vals []string) bool {
+	if n.op == "!=" {
+		return !n.matchTextsEqual(vals)
+	}
+	if n.op == "contains" {
+		want := strings.ToLower(n.texts[0])
+		for _, val := range vals {
+			if strings.Contains(strings.ToLower(val), want) {
+				return true
+			}
+		}
+		return false
+	}
+	return n.matchTextsEqual(vals)
+}
+
+// matchTextsEqual reports whether any text is one of the comparison values.
+func (n *comparison) matchTextsEqual(vals []string) bool {
+	for _, val := range vals {
+		for _, want := range n.texts {
+			if strings.EqualFold(val, want) {
+				return true
+			}
+			// a bare language matches any of its regional variants
+			if n.field.language && !strings.Contains(want, "-") {
+				if prefix := strings.SplitN(val, "-", 2)[0]; strings.EqualFold(prefix, want) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// String returns a normalized rendering of the comparison.
+func (n *comparison) String() string {
+	var vals []string
+	for _, val := range n.enums {
+		vals = append(vals, n.field.names[val])
+	}
+	for _, val := range n.texts {
+		vals = append(vals, quote(val))
+	}
+	field := n.field.name
+	if n.field.keyed {
+		field += " " + quote(n.key)
+	}
+	if n.op == "in" {
+		return field + " in (" + strings.Join(vals, ", ") + ")"
+	}
+	return field + " " + n.op + " " + vals[0]
+}
+
+// quote wraps a value in quotes if it would not lex as a single word.
+func quote(val string) string {
+	if strings.Contains(val, `"`) {
+		return "'" + val + "'"
+	}
+	if val == "" || strings.ContainsAny(val, " \t\n\r(),=!<>'") {
+		return `"` + val + `"`
+	}
+	return val
+}
+
+// describe formats the values of a field for an Explanation.
+func describe(vals []string) string {
+	if len(vals) == 0 {
+		return "none"
+	}
+	quoted := make([]string, len(vals))
+	for i, val := range vals {
+		quoted[i] = quote(val)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filter_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/thetannerryan/cap"
+	"github.com/thetannerryan/cap/filter"
+)
+
+// test is a helper for the tests.
+func test(t *testing.T, name, expected, actual string) {
+	fmt.Printf(">> Testing %s\nExpected: %s\nActual:   %s\n", name, expected, actual)
+	if expected != actual {
+		t.Errorf("Incorrect output")
+	}
+}
+
+// load parses one of the example alerts.
+func load(name string) *cap.Alert {
+	contents, err := ioutil.ReadFile("../testing/" + name)
+	if err != nil {
+		panic(err)
+	}
+	alert, err := cap.ParseCAP(contents)
+	if err != nil {
+		panic(err)
+	}
+	return alert
+}
+
+// TestMatch tests expressions against the OASIS and NAADS examples.
+func TestMatch(t *testing.T) {
+	thunderstorm := load("Oasis_ThunderstormWarning.xml")
+	wind := load("PelmorexNAADS_WindWarning.xml")
+	earthquake := load("Oasis_EarthquakeReport.xml")
+
+	cases := []struct {
+		expr  string
+		alert *cap.Alert
+		match bool
+	}{
+		{"Severity >= Severe and Category in (Met, Fire) and Status == Actual", thunderstorm, true},
+		{"Severity >= Severe and Category in (Met, Fire) and Status == Actual", wind, false},
+		{"severity > minor", wind, true},
+		{"severity <= Minor", earthquake, true},
+		{"urgency == Past or certainty < Likely", earthquake, true},
+		{"eventCode SAME in (TOR, SVR)", thunderstorm, true},
+		{"eventCode SAME in (TOR, SVR)", wind, false},
+		{"language == fr and headline contains vent", wind, true},
+		{"language == en-CA and headline contains vent", wind, false},
+		{`parameter "layer:SOREM:2.0:WirelessImmediate" == No`, wind, true},
+		{`geocode "profile:CAP-CP:Location:0.3" == 2401`, wind, true},
+		{"not (msgType == Alert)", thunderstorm, false},
+		{"msgType != Alert and code == layer:SOREM:1.0", wind, true},
+		{"areaDesc contains 'tuolumne county'", thunderstorm, true},
+		{"category != Met", thunderstorm, false},
+	}
+	for _, c := range cases {
+		f, err := filter.Compile(c.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		test(t, c.expr, fmt.Sprint(c.match), fmt.Sprint(f.Match(c.alert)))
+	}
+}
+
+// TestSyntaxError tests that compilation errors carry the offending position.
+func TestSyntaxError(t *testing.T) {
+	cases := []struct {
+		expr string
+		pos  int
+	}{
+		{"severity >= Sever", 12},
+		{"headline > x", 9},
+		{"status == Actual and", 20},
+		{"categroy == Met", 0},
+		{"category in (Met Fire)", 17},
+		{"event == 'tornado", 9},
+		{"(status == Actual", 17},
+	}
+	for _, c := range cases {
+		_, err := filter.Compile(c.expr)
+		syntax, ok := err.(*filter.SyntaxError)
+		if !ok {
+			t.Fatalf("expected SyntaxError for %q, got %v", c.expr, err)
+		}
+		test(t, c.expr+" ("+syntax.Msg+")", fmt.Sprint(c.pos), fmt.Sprint(syntax.Pos))
+	}
+}
+
+// TestExplain tests that explanations name the deciding comparisons.
+func TestExplain(t *testing.T) {
+	wind := load("PelmorexNAADS_WindWarning.xml")
+
+	f := filter.MustCompile("language == en and severity >= moderate")
+	explanation := f.Explain(wind)
+	test(t, "Explain matched", "true", fmt.Sprint(explanation.Matched))
+	test(t, "Explain info", "1", fmt.Sprint(explanation.Info))
+	test(t, "Explain reasons", "language == en (got en-CA); severity >= Moderate (got Moderate)", strings.Join(explanation.Reasons, "; "))
+
+	f = filter.MustCompile("not status == Actual")
+	explanation = f.Explain(wind)
+	test(t, "Explain not matched", "false", fmt.Sprint(explanation.Matched))
+	test(t, "Explain not reasons", "not status == Actual (got Actual); not status == Actual (got Actual)", strings.Join(explanation.Reasons, "; "))
+
+	// codes kept by lenient parsing are explained by their original text
+	contents, err := ioutil.ReadFile("../testing/Oasis_ThunderstormWarning.xml")
+	if err != nil {
+		panic(err)
+	}
+	contents = bytes.Replace(contents, []byte("<status>Actual</status>"), []byte("<status>Live</status>"), 1)
+	contents = bytes.Replace(contents, []byte("<category>Met</category>"), []byte("<category>Weather</category>"), 1)
+	lenient, _, err := cap.ParseCAPWithOptions(contents, cap.ParseOptions{Lenient: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	explanation = filter.MustCompile("status == Actual or category == Met").Explain(lenient)
+	test(t, "Explain kept reasons", "status == Actual (got Live); category == Met (got Weather)", strings.Join(explanation.Reasons, "; "))
+}
+
+// BenchmarkMatch measures evaluation of a compiled expression.
+func BenchmarkMatch(b *testing.B) {
+	wind := load("PelmorexNAADS_WindWarning.xml")
+	f := filter.MustCompile("severity >= Severe and category in (Met, Fire) or eventCode SAME in (TOR, SVR, HWW)")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Match(wind)
+	}
+}
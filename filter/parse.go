@@ -0,0 +1,254 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filter
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SyntaxError describes a filter expression that could not be compiled. Pos is
+// the byte offset of the offending token within the expression.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+// Error returns the message and position of the SyntaxError.
+func (e *SyntaxError) Error() string {
+	return "Error: " + e.Msg + " at position " + strconv.Itoa(e.Pos)
+}
+
+// tokenKind is the lexical class of a token.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+// token is a single lexical element of an expression.
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// describe returns a human readable form of the token for error messages.
+func (t token) describe() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+// is reports whether the token is the given case-insensitive keyword.
+func (t token) is(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+// lex splits an expression into tokens.
+func lex(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokenLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenRParen, ")", i})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{tokenComma, ",", i})
+			i++
+		case c == '=' || c == '!' || c == '<' || c == '>':
+			start := i
+			i++
+			if i < len(expr) && expr[i] == '=' {
+				i++
+			}
+			op := expr[start:i]
+			if op == "=" || op == "!" {
+				return nil, &SyntaxError{start, "illegal operator " + strconv.Quote(op)}
+			}
+			tokens = append(tokens, token{tokenOp, op, start})
+		case c == '"' || c == '\'':
+			start := i
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, &SyntaxError{start, "unterminated string"}
+			}
+			tokens = append(tokens, token{tokenString, expr[i+1 : i+1+end], start})
+			i += end + 2
+		default:
+			start := i
+			for i < len(expr) && !strings.ContainsRune(" \t\n\r(),=!<>\"'", rune(expr[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokenWord, expr[start:i], start})
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(expr)}), nil
+}
+
+// parser is a recursive descent parser over a token stream.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// peek returns the current token.
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// next consumes and returns the current token.
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseOr parses a disjunction, the lowest precedence level.
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left, right}
+	}
+	return left, nil
+}
+
+// parseAnd parses a conjunction.
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left, right}
+	}
+	return left, nil
+}
+
+// parseNot parses an optionally negated primary expression.
+func (p *parser) parseNot() (node, error) {
+	if p.peek().is("not") {
+		p.next()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses a parenthesized expression or a comparison.
+func (p *parser) parsePrimary() (node, error) {
+	if p.peek().kind == tokenLParen {
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != tokenRParen {
+			return nil, &SyntaxError{tok.pos, "expected \")\" but found " + tok.describe()}
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses a field, an optional key, an operator and one or more
+// values.
+func (p *parser) parseComparison() (node, error) {
+	tok := p.next()
+	if tok.kind != tokenWord {
+		return nil, &SyntaxError{tok.pos, "expected field but found " + tok.describe()}
+	}
+	f, ok := fields[strings.ToLower(tok.text)]
+	if !ok {
+		return nil, &SyntaxError{tok.pos, "unknown field " + strconv.Quote(tok.text)}
+	}
+	cmp := &comparison{field: f}
+
+	if f.keyed {
+		key := p.next()
+		if key.kind != tokenWord && key.kind != tokenString {
+			return nil, &SyntaxError{key.pos, "expected " + f.name + " name but found " + key.describe()}
+		}
+		cmp.key = key.text
+	}
+
+	op := p.next()
+	switch {
+	case op.kind == tokenOp:
+		cmp.op = op.text
+	case op.is("in"), op.is("contains"):
+		cmp.op = strings.ToLower(op.text)
+	default:
+		return nil, &SyntaxError{op.pos, "expected operator but found " + op.describe()}
+	}
+	if err := f.supports(cmp.op); err != nil {
+		return nil, &SyntaxError{op.pos, err.Error()}
+	}
+
+	var values []token
+	if cmp.op == "in" {
+		if tok := p.next(); tok.kind != tokenLParen {
+			return nil, &SyntaxError{tok.pos, "expected \"(\" but found " + tok.describe()}
+		}
+		for {
+			value := p.next()
+			if value.kind != tokenWord && value.kind != tokenString {
+				return nil, &SyntaxError{value.pos, "expected value but found " + value.describe()}
+			}
+			values = append(values, value)
+			sep := p.next()
+			if sep.kind == tokenRParen {
+				break
+			}
+			if sep.kind != tokenComma {
+				return nil, &SyntaxError{sep.pos, "expected \",\" or \")\" but found " + sep.describe()}
+			}
+		}
+	} else {
+		value := p.next()
+		if value.kind != tokenWord && value.kind != tokenString {
+			return nil, &SyntaxError{value.pos, "expected value but found " + value.describe()}
+		}
+		values = append(values, value)
+	}
+
+	for _, value := range values {
+		if err := cmp.addValue(value.text); err != nil {
+			return nil, &SyntaxError{value.pos, err.Error()}
+		}
+	}
+	return cmp, nil
+}
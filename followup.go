@@ -0,0 +1,116 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap
+
+import (
+	"strings"
+	"time"
+)
+
+// Reference returns the "sender,identifier,sent" form of the Alert, as used
+// within the references element of later messages.
+func (t *Alert) Reference() string {
+	return t.Sender + "," + t.Identifier + "," + t.Sent.String()
+}
+
+// followUp returns a builder for a message of the type from the sender that
+// refers to the Alert. The message has a new identifier and is sent now. It
+// keeps the status, scope, restriction, addresses and incidents of the Alert.
+func (t *Alert) followUp(sender string, msgType MsgType, references []string) *AlertBuilder {
+	return &AlertBuilder{alert: Alert{
+		Identifier:  newIdentifier(),
+		Sender:      sender,
+		Sent:        NewDateTime(time.Now()),
+		Status:      t.Status,
+		MsgType:     msgType,
+		Scope:       t.Scope,
+		Restriction: t.Restriction,
+		Addresses:   t.Addresses,
+		References:  NewList(references...),
+		Incidents:   t.Incidents,
+
+		Unrecognized: append([]string(nil), t.Unrecognized...),
+	}}
+}
+
+// chain returns the references of the Alert followed by the Alert itself, for
+// messages that supersede it.
+func (t *Alert) chain() []string {
+	var references []string
+	for _, reference := range t.References.Values() {
+		if reference != "" {
+			references = append(references, reference)
+		}
+	}
+	return append(references, t.Reference())
+}
+
+// withInfo copies the Info blocks of the Alert into the builder.
+func (b *AlertBuilder) withInfo(infos []Info) *AlertBuilder {
+	for _, info := range infos {
+		infoBuilder := &InfoBuilder{info: info, urgency: true, severity: true, certainty: true}
+		infoBuilder.info = infoBuilder.build()
+		for _, area := range info.Area {
+			areaBuilder := &AreaBuilder{area: area}
+			areaBuilder.area = areaBuilder.build()
+			infoBuilder.areas = append(infoBuilder.areas, areaBuilder)
+		}
+		infoBuilder.info.Area = nil
+		b.infos = append(b.infos, infoBuilder)
+	}
+	return b
+}
+
+// NewUpdate returns a builder for an Update message superseding the Alert. The
+// source, codes and Info blocks of the Alert are copied, to be amended before
+// Build. Its references are those of the Alert, followed by the Alert.
+func (t *Alert) NewUpdate() *AlertBuilder {
+	b := t.followUp(t.Sender, MsgTypeUpdate, t.chain())
+	b.alert.Source = t.Source
+	b.alert.Code = append([]string(nil), t.Code...)
+	return b.withInfo(t.Info)
+}
+
+// NewCancel returns a builder for a Cancel message cancelling the Alert, with
+// the note describing the reason. The source, codes and Info blocks of the
+// Alert are copied. Its references are those of the Alert, followed by the
+// Alert.
+func (t *Alert) NewCancel(note string) *AlertBuilder {
+	b := t.followUp(t.Sender, MsgTypeCancel, t.chain())
+	b.alert.Source = t.Source
+	b.alert.Code = append([]string(nil), t.Code...)
+	b.alert.Note = note
+	return b.withInfo(t.Info)
+}
+
+// reply returns a builder for an Ack or Error message from the sender,
+// answering the Alert. Its only reference is the Alert, and it is addressed
+// privately to the sender of the Alert, rather than to its recipients.
+func (t *Alert) reply(sender string, msgType MsgType) *AlertBuilder {
+	b := t.followUp(sender, msgType, []string{t.Reference()})
+	b.alert.Scope = ScopePrivate
+	b.alert.Restriction = ""
+	b.alert.Addresses = List{}
+	if strings.TrimSpace(t.Sender) != "" {
+		b.alert.Addresses = NewList(t.Sender)
+	}
+	return b
+}
+
+// NewAck returns a builder for an Ack message from the sender, acknowledging
+// receipt of the Alert. Its only reference is the Alert, and it is addressed
+// privately to the sender of the Alert.
+func (t *Alert) NewAck(sender string) *AlertBuilder {
+	return t.reply(sender, MsgTypeAck)
+}
+
+// NewError returns a builder for an Error message from the sender, rejecting
+// the Alert with the note explaining the error. Its only reference is the
+// Alert, and it is addressed privately to the sender of the Alert.
+func (t *Alert) NewError(sender, note string) *AlertBuilder {
+	b := t.reply(sender, MsgTypeError)
+	b.alert.Note = note
+	return b
+}
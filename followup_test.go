@@ -0,0 +1,74 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/thetannerryan/cap"
+)
+
+// TestFollowUp tests the references and copied elements of follow-up messages
+// to the NAADS example.
+func TestFollowUp(t *testing.T) {
+	contents, err := ioutil.ReadFile("testing/PelmorexNAADS_WindWarning.xml")
+	if err != nil {
+		panic(err)
+	}
+	alert, err := cap.ParseCAP(contents)
+	if err != nil {
+		panic(err)
+	}
+	original := "cap-pac@canada.ca,urn:oid:2.49.0.1.124.3936999913.2019,2019-01-09T02:17:03-00:00"
+	test(t, "Reference", original, alert.Reference())
+
+	builder := alert.NewUpdate()
+	builder.Info(0).Headline("avertissement de vent - mis à jour")
+	update, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Update msgType", "Update", update.MsgType.String())
+	test(t, "Update references", alert.References.String()+" "+original, update.References.String())
+	test(t, "Update identifier", "false", fmt.Sprint(update.Identifier == alert.Identifier))
+	test(t, "Update headline", "avertissement de vent - mis à jour", update.Info[0].Headline)
+	test(t, "Original headline", "avertissement de vent en vigueur", alert.Info[0].Headline)
+	test(t, "Update area", alert.Info[0].Area[0].AreaDesc, update.Info[0].Area[0].AreaDesc)
+	test(t, "Update code", fmt.Sprint(alert.Code), fmt.Sprint(update.Code))
+
+	cancel, err := alert.NewCancel("issued in error").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Cancel msgType", "Cancel", cancel.MsgType.String())
+	test(t, "Cancel note", "issued in error", cancel.Note)
+	test(t, "Cancel infos", fmt.Sprint(len(alert.Info)), fmt.Sprint(len(cancel.Info)))
+
+	ack, err := alert.NewAck("receiver@example.org").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Ack msgType", "Ack", ack.MsgType.String())
+	test(t, "Ack sender", "receiver@example.org", ack.Sender)
+	test(t, "Ack references", original, ack.References.String())
+	test(t, "Ack status", alert.Status.String(), ack.Status.String())
+	test(t, "Ack infos", "0", fmt.Sprint(len(ack.Info)))
+	test(t, "Ack scope", "Private", ack.Scope.String())
+	test(t, "Ack addresses", "cap-pac@canada.ca", ack.Addresses.String())
+
+	rejection, err := alert.NewError("receiver@example.org", "unknown sender").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Error sender", "receiver@example.org", rejection.Sender)
+	test(t, "Error addresses", "cap-pac@canada.ca", rejection.Addresses.String())
+	test(t, "Error msgType", "Error", rejection.MsgType.String())
+	test(t, "Error note", "unknown sender", rejection.Note)
+
+	_, err = alert.NewAck("").Build()
+	test(t, "Ack without sender", "Error: missing required element at alert/sender", fmt.Sprint(err))
+}
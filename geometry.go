@@ -0,0 +1,559 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// earthRadiusKm is the mean radius of the WGS 84 ellipsoid, in kilometers.
+const earthRadiusKm = 6371.0088
+
+// ErrNoGeometry is returned when a centroid is requested for an Area, Info or
+// Alert that contains no polygon or circle.
+var ErrNoGeometry = errors.New("Error: no polygon or circle geometry")
+
+// Point is a WGS 84 coordinate pair, as used within the polygon and circle
+// elements of an Area.
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// parsePoint will return a Point given a CAP "latitude,longitude" pair. If the
+// pair is not formatted correctly, an error will be returned.
+func parsePoint(val string) (Point, error) {
+	pair := strings.Split(val, ",")
+	if len(pair) != 2 {
+		return Point{}, errors.New("Error: illegal value " + val + " for Point")
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(pair[0]), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return Point{}, errors.New("Error: illegal latitude " + pair[0] + " for Point")
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(pair[1]), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return Point{}, errors.New("Error: illegal longitude " + pair[1] + " for Point")
+	}
+	return Point{Latitude: lat, Longitude: lon}, nil
+}
+
+// String returns the CAP "latitude,longitude" representation of the Point.
+func (p Point) String() string {
+	return strconv.FormatFloat(p.Latitude, 'f', -1, 64) + "," +
+		strconv.FormatFloat(p.Longitude, 'f', -1, 64)
+}
+
+// DistanceKm returns the great-circle distance between two Points, in
+// kilometers.
+func (p Point) DistanceKm(q Point) float64 {
+	lat1, lat2 := radians(p.Latitude), radians(q.Latitude)
+	dLat := lat2 - lat1
+	dLon := radians(q.Longitude - p.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Circle is a center Point and a radius in kilometers, as used within the
+// circle element of an Area.
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// parseCircle will return a Circle given a CAP "latitude,longitude radius"
+// value. If the value is not formatted correctly, an error will be returned.
+func parseCircle(val string) (Circle, error) {
+	fields := strings.Fields(val)
+	if len(fields) != 2 {
+		return Circle{}, errors.New("Error: illegal value " + val + " for Circle")
+	}
+	center, err := parsePoint(fields[0])
+	if err != nil {
+		return Circle{}, err
+	}
+	radius, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil || radius < 0 {
+		return Circle{}, errors.New("Error: illegal radius " + fields[1] + " for Circle")
+	}
+	return Circle{Center: center, Radius: radius}, nil
+}
+
+// String returns the CAP "latitude,longitude radius" representation of the
+// Circle.
+func (c Circle) String() string {
+	return c.Center.String() + " " + strconv.FormatFloat(c.Radius, 'f', -1, 64)
+}
+
+// PolygonPoints returns the parsed vertices of the Area polygon. If the Area
+// has no polygon, an empty slice is returned.
+func (a *Area) PolygonPoints() ([]Point, error) {
+	fields := strings.Fields(a.Polygon.String())
+	points := make([]Point, 0, len(fields))
+	for _, field := range fields {
+		point, err := parsePoint(field)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// Circles returns the parsed circles of the Area.
+func (a *Area) Circles() ([]Circle, error) {
+	circles := make([]Circle, 0, len(a.Circle))
+	for _, val := range a.Circle {
+		if strings.TrimSpace(val) == "" {
+			continue
+		}
+		circle, err := parseCircle(val)
+		if err != nil {
+			return nil, err
+		}
+		circles = append(circles, circle)
+	}
+	return circles, nil
+}
+
+// BBox is a latitude / longitude bounding box. A BBox that crosses the
+// antimeridian has a MinLon greater than its MaxLon.
+type BBox struct {
+	MinLat float64
+	MinLon float64
+	MaxLat float64
+	MaxLon float64
+}
+
+// emptyBBox is the identity for BBox unions.
+var emptyBBox = BBox{
+	MinLat: math.Inf(1),
+	MinLon: math.Inf(1),
+	MaxLat: math.Inf(-1),
+	MaxLon: math.Inf(-1),
+}
+
+// Empty reports whether the BBox contains no points, which is the case for an
+// Area without a polygon or circle.
+func (b BBox) Empty() bool {
+	return b.MinLat > b.MaxLat
+}
+
+// CrossesAntimeridian reports whether the BBox spans the 180th meridian.
+func (b BBox) CrossesAntimeridian() bool {
+	return !b.Empty() && b.MinLon > b.MaxLon
+}
+
+// Contains reports whether the Point lies within the BBox.
+func (b BBox) Contains(p Point) bool {
+	if b.Empty() || p.Latitude < b.MinLat || p.Latitude > b.MaxLat {
+		return false
+	}
+	if b.CrossesAntimeridian() {
+		return p.Longitude >= b.MinLon || p.Longitude <= b.MaxLon
+	}
+	return p.Longitude >= b.MinLon && p.Longitude <= b.MaxLon
+}
+
+// Intersects reports whether the two BBoxes share at least one point.
+func (b BBox) Intersects(o BBox) bool {
+	if b.Empty() || o.Empty() || b.MinLat > o.MaxLat || o.MinLat > b.MaxLat {
+		return false
+	}
+	return lonOffset(b.MinLon, o.MinLon) <= lonWidth(b.MinLon, b.MaxLon) ||
+		lonOffset(o.MinLon, b.MinLon) <= lonWidth(o.MinLon, o.MaxLon)
+}
+
+// Union returns the smallest BBox containing both BBoxes. Where the longitude
+// ranges may be joined either way around the globe, the narrower is chosen.
+func (b BBox) Union(o BBox) BBox {
+	if b.Empty() {
+		return o
+	}
+	if o.Empty() {
+		return b
+	}
+	union := BBox{
+		MinLat: math.Min(b.MinLat, o.MinLat),
+		MaxLat: math.Max(b.MaxLat, o.MaxLat),
+	}
+	bWidth, oWidth := lonWidth(b.MinLon, b.MaxLon), lonWidth(o.MinLon, o.MaxLon)
+	fromB := math.Max(bWidth, lonOffset(b.MinLon, o.MinLon)+oWidth)
+	fromO := math.Max(oWidth, lonOffset(o.MinLon, b.MinLon)+bWidth)
+	switch {
+	case math.Min(fromB, fromO) >= 360:
+		union.MinLon, union.MaxLon = -180, 180
+	case fromB <= fromO:
+		union.MinLon, union.MaxLon = b.MinLon, normalizeLon(b.MinLon+fromB)
+	default:
+		union.MinLon, union.MaxLon = o.MinLon, normalizeLon(o.MinLon+fromO)
+	}
+	return union
+}
+
+// lonWidth returns the eastward extent, in degrees, from min to max.
+func lonWidth(min, max float64) float64 {
+	if min <= max {
+		return max - min
+	}
+	return max + 360 - min
+}
+
+// lonOffset returns the eastward distance, in degrees, from a to b.
+func lonOffset(a, b float64) float64 {
+	return math.Mod(b-a+360, 360)
+}
+
+// normalizeLon wraps a longitude into the range [-180, 180].
+func normalizeLon(lon float64) float64 {
+	for lon > 180 {
+		lon -= 360
+	}
+	for lon < -180 {
+		lon += 360
+	}
+	return lon
+}
+
+// pointsBBox returns the BBox of a set of Points. The longitude range is the
+// complement of the largest gap between points, so that sets of points on
+// either side of the antimeridian produce a narrow, crossing BBox.
+func pointsBBox(points []Point) BBox {
+	if len(points) == 0 {
+		return emptyBBox
+	}
+	bbox := emptyBBox
+	lons := make([]float64, len(points))
+	for i, point := range points {
+		bbox.MinLat = math.Min(bbox.MinLat, point.Latitude)
+		bbox.MaxLat = math.Max(bbox.MaxLat, point.Latitude)
+		lons[i] = point.Longitude
+	}
+	sort.Float64s(lons)
+	// the gap across the antimeridian is the default
+	gap := lons[0] + 360 - lons[len(lons)-1]
+	bbox.MinLon, bbox.MaxLon = lons[0], lons[len(lons)-1]
+	for i := 1; i < len(lons); i++ {
+		if lons[i]-lons[i-1] > gap {
+			gap = lons[i] - lons[i-1]
+			bbox.MinLon, bbox.MaxLon = lons[i], lons[i-1]
+		}
+	}
+	return bbox
+}
+
+// circleBBox returns the BBox of a Circle, expanded by its radius.
+func circleBBox(c Circle) BBox {
+	angular := c.Radius / earthRadiusKm
+	dLat := degrees(angular)
+	bbox := BBox{
+		MinLat: c.Center.Latitude - dLat,
+		MaxLat: c.Center.Latitude + dLat,
+	}
+	// a circle that reaches a pole covers every longitude
+	if bbox.MinLat <= -90 || bbox.MaxLat >= 90 {
+		bbox.MinLat, bbox.MaxLat = math.Max(bbox.MinLat, -90), math.Min(bbox.MaxLat, 90)
+		bbox.MinLon, bbox.MaxLon = -180, 180
+		return bbox
+	}
+	ratio := math.Sin(angular) / math.Cos(radians(c.Center.Latitude))
+	if ratio >= 1 {
+		bbox.MinLon, bbox.MaxLon = -180, 180
+		return bbox
+	}
+	dLon := degrees(math.Asin(ratio))
+	bbox.MinLon = normalizeLon(c.Center.Longitude - dLon)
+	bbox.MaxLon = normalizeLon(c.Center.Longitude + dLon)
+	return bbox
+}
+
+// Polygon returns a closed ring of the given number of sides approximating the
+// Circle. The vertices run counter-clockwise, as RFC 7946 requires of exterior
+// rings. Longitudes are kept within 180 degrees of the center, so the ring of a
+// circle crossing the antimeridian extends past -180 or 180 rather than jumping
+// across the map. If sides is less than three, nil is returned.
+func (c Circle) Polygon(sides int) []Point {
+	if sides < 3 {
+		return nil
+	}
+	lat1, lon1 := radians(c.Center.Latitude), radians(c.Center.Longitude)
+	angular := c.Radius / earthRadiusKm
+	ring := make([]Point, 0, sides+1)
+	for i := 0; i < sides; i++ {
+		// bearings are clockwise from north, so they are walked backwards
+		bearing := -2 * math.Pi * float64(i) / float64(sides)
+		lat2 := math.Asin(math.Sin(lat1)*math.Cos(angular) + math.Cos(lat1)*math.Sin(angular)*math.Cos(bearing))
+		dLon := math.Atan2(math.Sin(bearing)*math.Sin(angular)*math.Cos(lat1), math.Cos(angular)-math.Sin(lat1)*math.Sin(lat2))
+		ring = append(ring, Point{Latitude: degrees(lat2), Longitude: degrees(lon1 + dLon)})
+	}
+	return append(ring, ring[0])
+}
+
+// BBox returns the bounding box covering the polygon and circles of the Area.
+// If the Area has neither, an empty BBox is returned.
+func (a *Area) BBox() (BBox, error) {
+	points, err := a.PolygonPoints()
+	if err != nil {
+		return emptyBBox, err
+	}
+	circles, err := a.Circles()
+	if err != nil {
+		return emptyBBox, err
+	}
+	bbox := pointsBBox(points)
+	for _, circle := range circles {
+		bbox = bbox.Union(circleBBox(circle))
+	}
+	return bbox, nil
+}
+
+// BBox returns the bounding box covering every Area of the Info.
+func (t *Info) BBox() (BBox, error) {
+	bbox := emptyBBox
+	for i := range t.Area {
+		areaBBox, err := t.Area[i].BBox()
+		if err != nil {
+			return emptyBBox, err
+		}
+		bbox = bbox.Union(areaBBox)
+	}
+	return bbox, nil
+}
+
+// BBox returns the bounding box covering every Area of every Info of the
+// Alert.
+func (t *Alert) BBox() (BBox, error) {
+	bbox := emptyBBox
+	for i := range t.Info {
+		infoBBox, err := t.Info[i].BBox()
+		if err != nil {
+			return emptyBBox, err
+		}
+		bbox = bbox.Union(infoBBox)
+	}
+	return bbox, nil
+}
+
+// polygonAreaKm2 returns the approximate spherical area of a closed ring of
+// Points, in square kilometers.
+func polygonAreaKm2(points []Point) float64 {
+	if len(points) < 3 {
+		return 0
+	}
+	var sum float64
+	for i := range points {
+		p, q := points[i], points[(i+1)%len(points)]
+		dLon := normalizeLon(q.Longitude - p.Longitude)
+		sum += radians(dLon) * (2 + math.Sin(radians(p.Latitude)) + math.Sin(radians(q.Latitude)))
+	}
+	return math.Abs(sum) * earthRadiusKm * earthRadiusKm / 2
+}
+
+// circleAreaKm2 returns the area of the spherical cap described by the Circle,
+// in square kilometers.
+func circleAreaKm2(c Circle) float64 {
+	return 2 * math.Pi * earthRadiusKm * earthRadiusKm * (1 - math.Cos(c.Radius/earthRadiusKm))
+}
+
+// ApproxAreaKm2 returns the approximate surface area covered by the polygon and
+// circles of the Area, in square kilometers. Overlapping shapes are counted
+// once per shape.
+func (a *Area) ApproxAreaKm2() (float64, error) {
+	points, err := a.PolygonPoints()
+	if err != nil {
+		return 0, err
+	}
+	circles, err := a.Circles()
+	if err != nil {
+		return 0, err
+	}
+	total := polygonAreaKm2(points)
+	for _, circle := range circles {
+		total += circleAreaKm2(circle)
+	}
+	return total, nil
+}
+
+// weightedPoint is a centroid and the weight it contributes to a larger
+// centroid computation.
+type weightedPoint struct {
+	point  Point
+	weight float64
+}
+
+// polygonCentroid returns the area-weighted centroid of a closed ring of
+// Points, using an equirectangular projection about the first vertex. A
+// degenerate ring falls back to the mean of its vertices.
+func polygonCentroid(points []Point) Point {
+	origin := points[0]
+	scale := math.Cos(radians(origin.Latitude))
+	xs := make([]float64, len(points))
+	ys := make([]float64, len(points))
+	for i, point := range points {
+		xs[i] = normalizeLon(point.Longitude-origin.Longitude) * scale
+		ys[i] = point.Latitude
+	}
+	var area, cx, cy float64
+	for i := range points {
+		j := (i + 1) % len(points)
+		cross := xs[i]*ys[j] - xs[j]*ys[i]
+		area += cross
+		cx += (xs[i] + xs[j]) * cross
+		cy += (ys[i] + ys[j]) * cross
+	}
+	if math.Abs(area) < 1e-12 {
+		weights := make([]weightedPoint, len(points))
+		for i, point := range points {
+			weights[i] = weightedPoint{point: point}
+		}
+		return meanPoint(weights)
+	}
+	area /= 2
+	return Point{
+		Latitude:  cy / (6 * area),
+		Longitude: normalizeLon(origin.Longitude + cx/(6*area)/scale),
+	}
+}
+
+// meanPoint returns the weighted mean of the Points, unwrapping longitudes
+// about the first Point so that the antimeridian is handled. If every weight is
+// zero, each Point counts equally.
+func meanPoint(points []weightedPoint) Point {
+	var total float64
+	for _, wp := range points {
+		total += wp.weight
+	}
+	origin := points[0].point.Longitude
+	var lat, lon float64
+	for _, wp := range points {
+		weight := wp.weight
+		if total == 0 {
+			weight = 1
+		}
+		lat += wp.point.Latitude * weight
+		lon += (origin + normalizeLon(wp.point.Longitude-origin)) * weight
+	}
+	if total == 0 {
+		total = float64(len(points))
+	}
+	return Point{Latitude: lat / total, Longitude: normalizeLon(lon / total)}
+}
+
+// centroids returns the centroid and area of each shape within the Area.
+func (a *Area) centroids() ([]weightedPoint, error) {
+	points, err := a.PolygonPoints()
+	if err != nil {
+		return nil, err
+	}
+	circles, err := a.Circles()
+	if err != nil {
+		return nil, err
+	}
+	var weights []weightedPoint
+	if len(points) > 0 {
+		weights = append(weights, weightedPoint{polygonCentroid(points), polygonAreaKm2(points)})
+	}
+	for _, circle := range circles {
+		weights = append(weights, weightedPoint{circle.Center, circleAreaKm2(circle)})
+	}
+	return weights, nil
+}
+
+// Centroid returns the area-weighted center of the polygon and circles of the
+// Area. If the Area has neither, ErrNoGeometry is returned.
+func (a *Area) Centroid() (Point, error) {
+	weights, err := a.centroids()
+	if err != nil {
+		return Point{}, err
+	}
+	if len(weights) == 0 {
+		return Point{}, ErrNoGeometry
+	}
+	return meanPoint(weights), nil
+}
+
+// Centroid returns the area-weighted center of every Area of the Info. Areas
+// described only by text or geocodes are skipped. If no Area has a polygon or
+// circle, ErrNoGeometry is returned.
+func (t *Info) Centroid() (Point, error) {
+	var weights []weightedPoint
+	for i := range t.Area {
+		areaWeights, err := t.Area[i].centroids()
+		if err != nil {
+			return Point{}, err
+		}
+		weights = append(weights, areaWeights...)
+	}
+	if len(weights) == 0 {
+		return Point{}, ErrNoGeometry
+	}
+	return meanPoint(weights), nil
+}
+
+// radians converts degrees to radians.
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// degrees converts radians to degrees.
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// containsPoint reports whether the Point lies within the closed ring of
+// Points. Longitudes are unwrapped about the first vertex so that rings
+// crossing the antimeridian are handled.
+func containsPoint(ring []Point, p Point) bool {
+	origin := ring[0].Longitude
+	xs := make([]float64, len(ring))
+	xs[0] = origin
+	for i := 1; i < len(ring); i++ {
+		xs[i] = xs[i-1] + normalizeLon(ring[i].Longitude-ring[i-1].Longitude)
+	}
+	px := origin + normalizeLon(p.Longitude-origin)
+
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		yi, yj := ring[i].Latitude, ring[j].Latitude
+		if (yi > p.Latitude) != (yj > p.Latitude) &&
+			px < (xs[j]-xs[i])*(p.Latitude-yi)/(yj-yi)+xs[i] {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Contains reports whether the Point lies within the polygon or any circle of
+// the Area. An Area described only by text or geocodes contains no Points.
+func (a *Area) Contains(p Point) (bool, error) {
+	points, err := a.PolygonPoints()
+	if err != nil {
+		return false, err
+	}
+	circles, err := a.Circles()
+	if err != nil {
+		return false, err
+	}
+	return shapesContain(points, circles, p), nil
+}
+
+// shapesContain reports whether the Point lies within the polygon or any of the
+// circles.
+func shapesContain(polygon []Point, circles []Circle, p Point) bool {
+	if len(polygon) >= 3 && containsPoint(polygon, p) {
+		return true
+	}
+	for _, circle := range circles {
+		if circle.Center.DistanceKm(p) <= circle.Radius {
+			return true
+		}
+	}
+	return false
+}
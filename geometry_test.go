@@ -0,0 +1,142 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"github.com/thetannerryan/cap"
+)
+
+// bboxString formats a BBox for comparison in tests.
+func bboxString(b cap.BBox) string {
+	return fmt.Sprintf("%.4f,%.4f %.4f,%.4f", b.MinLat, b.MinLon, b.MaxLat, b.MaxLon)
+}
+
+// TestGeometryThunderstormWarning tests the bounding box, area and centroid of
+// the OASIS Severe Thunderstorm Warning polygon.
+func TestGeometryThunderstormWarning(t *testing.T) {
+	contents, err := ioutil.ReadFile("testing/Oasis_ThunderstormWarning.xml")
+	if err != nil {
+		panic(err)
+	}
+	alert, err := cap.ParseCAP(contents)
+	if err != nil {
+		panic(err)
+	}
+
+	bbox, err := alert.BBox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Thunderstorm bbox", "38.3400,-120.1400 38.6200,-119.7400", bboxString(bbox))
+	test(t, "Thunderstorm bbox crosses", "false", fmt.Sprint(bbox.CrossesAntimeridian()))
+
+	area, err := alert.Info[0].Area[0].ApproxAreaKm2()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Thunderstorm area", "528", fmt.Sprintf("%.0f", area))
+
+	centroid, err := alert.Info[0].Centroid()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Thunderstorm centroid", "38.48,-119.93", fmt.Sprintf("%.2f,%.2f", centroid.Latitude, centroid.Longitude))
+}
+
+// TestGeometryCircle tests that circles are expanded by their radius and that
+// zero radius circles collapse to their center.
+func TestGeometryCircle(t *testing.T) {
+	contents, err := ioutil.ReadFile("testing/Oasis_EarthquakeReport.xml")
+	if err != nil {
+		panic(err)
+	}
+	alert, err := cap.ParseCAP(contents)
+	if err != nil {
+		panic(err)
+	}
+
+	bbox, err := alert.BBox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Earthquake bbox", "32.9525,-115.5527 32.9525,-115.5527", bboxString(bbox))
+	centroid, err := alert.Info[0].Area[0].Centroid()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Earthquake centroid", "32.9525,-115.5527", centroid.String())
+
+	area := cap.Area{Circle: []string{"0,0 111.19508"}}
+	bbox, err = area.BBox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Circle bbox", "-1.0000,-1.0000 1.0000,1.0000", bboxString(bbox))
+
+	_, err = (&cap.Area{}).Centroid()
+	test(t, "Circle no geometry", cap.ErrNoGeometry.Error(), fmt.Sprint(err))
+}
+
+// TestGeometryAntimeridian tests polygons and circles that cross the 180th
+// meridian.
+func TestGeometryAntimeridian(t *testing.T) {
+	alert, err := cap.ParseCAP([]byte(`<alert xmlns="urn:oasis:names:tc:emergency:cap:1.2"><info><area>
+		<polygon>-16,179 -16,-179 -18,-179 -18,179 -16,179</polygon>
+		<circle>-17,-179.9 111.19508</circle>
+	</area></info></alert>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	polygon := alert.Info[0].Area[0]
+
+	bbox, err := polygon.BBox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Antimeridian bbox", "-18.0000,179.0000 -16.0000,-178.8543", bboxString(bbox))
+	test(t, "Antimeridian bbox crosses", "true", fmt.Sprint(bbox.CrossesAntimeridian()))
+	test(t, "Antimeridian contains east", "true", fmt.Sprint(bbox.Contains(cap.Point{Latitude: -17, Longitude: 179.5})))
+	test(t, "Antimeridian contains west", "true", fmt.Sprint(bbox.Contains(cap.Point{Latitude: -17, Longitude: -179.5})))
+	test(t, "Antimeridian excludes", "false", fmt.Sprint(bbox.Contains(cap.Point{Latitude: -17, Longitude: 0})))
+
+	centroid, err := polygon.Centroid()
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Antimeridian centroid latitude", "-17.0", fmt.Sprintf("%.1f", centroid.Latitude))
+}
+
+// TestCirclePolygon tests the rings approximating circles, including one that
+// crosses the 180th meridian.
+func TestCirclePolygon(t *testing.T) {
+	for _, circle := range []cap.Circle{
+		{Center: cap.Point{Latitude: 46.2, Longitude: -74.5}, Radius: 10},
+		{Center: cap.Point{Latitude: -17, Longitude: 179.9}, Radius: 111.19508},
+	} {
+		ring := circle.Polygon(32)
+		test(t, circle.String()+" vertices", "33", fmt.Sprint(len(ring)))
+		test(t, circle.String()+" closed", "true", fmt.Sprint(ring[0] == ring[len(ring)-1]))
+		area := 0.0
+		for i, p := range ring {
+			if math.Abs(p.DistanceKm(circle.Center)-circle.Radius) > 0.001 {
+				t.Errorf("Vertex %s is %f km from the center", p, p.DistanceKm(circle.Center))
+			}
+			if i > 0 {
+				q := ring[i-1]
+				area += q.Longitude*p.Latitude - p.Longitude*q.Latitude
+				if math.Abs(p.Longitude-q.Longitude) > 10 {
+					t.Errorf("Vertices %s and %s are not continuous", q, p)
+				}
+			}
+		}
+		test(t, circle.String()+" counter-clockwise", "true", fmt.Sprint(area > 0))
+	}
+	test(t, "Too few sides", "[]", fmt.Sprint((cap.Circle{Radius: 1}).Polygon(2)))
+}
@@ -1,3 +1,5 @@
 module github.com/thetannerryan/cap
 
-go 1.12
+go 1.13
+
+require google.golang.org/protobuf v1.31.0
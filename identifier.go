@@ -0,0 +1,163 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// IdentifierGenerator generates unique identifiers for new alert messages.
+type IdentifierGenerator interface {
+	NewIdentifier() string
+}
+
+// ValidateIdentifier returns an error if the identifier is empty, or contains
+// a space, comma or restricted character (< and &), which CAP 1.2 forbids in
+// the identifier and sender elements.
+func ValidateIdentifier(identifier string) error {
+	if identifier == "" {
+		return &valueError{
+			msg:    "Error: empty identifier",
+			kind:   ErrBadIdentifier,
+			offset: -1,
+		}
+	}
+	if i := strings.IndexAny(identifier, " \t\r\n,<&"); i >= 0 {
+		return &valueError{
+			msg:    "Error: illegal character " + strconv.QuoteRune(rune(identifier[i])) + " in identifier " + identifier,
+			value:  identifier,
+			kind:   ErrBadIdentifier,
+			offset: -1,
+		}
+	}
+	return nil
+}
+
+// random fills the buffer with cryptographically secure random bytes.
+func random(buff []byte) {
+	if _, err := rand.Read(buff); err != nil {
+		panic(err)
+	}
+}
+
+// UUIDGenerator generates random (version 4) UUIDs, such as
+// 0b2e4c1a-9f3d-4e8b-a7c6-5d1f2e3a4b5c.
+type UUIDGenerator struct{}
+
+// NewIdentifier returns a random UUID.
+func (UUIDGenerator) NewIdentifier() string {
+	var uuid [16]byte
+	random(uuid[:])
+	uuid[6] = uuid[6]&0x0f | 0x40
+	uuid[8] = uuid[8]&0x3f | 0x80
+	buff := hex.EncodeToString(uuid[:])
+	return buff[0:8] + "-" + buff[8:12] + "-" + buff[12:16] + "-" + buff[16:20] + "-" + buff[20:]
+}
+
+// OIDGenerator generates OID URN identifiers of the form
+// urn:oid:<arc>.<sequence>.<year>, as used by CAP-CP senders. The sequence
+// starts from 1 and restarts each year.
+type OIDGenerator struct {
+	arc   string
+	clock Clock
+
+	mu       sync.Mutex
+	year     int
+	sequence uint64
+}
+
+// NewOIDGenerator returns an OIDGenerator under the arc, such as
+// 2.49.0.1.124, reading the year from the clock. If the clock is nil, the
+// SystemClock is used.
+func NewOIDGenerator(arc string, clock Clock) *OIDGenerator {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &OIDGenerator{arc: arc, clock: clock}
+}
+
+// Resume continues the sequence of the year after the last sequence number
+// issued, such as after a restart.
+func (g *OIDGenerator) Resume(year int, last uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.year, g.sequence = year, last
+}
+
+// NewIdentifier returns the next identifier of the sequence.
+func (g *OIDGenerator) NewIdentifier() string {
+	year := g.clock.Now().UTC().Year()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if year != g.year {
+		g.year, g.sequence = year, 0
+	}
+	g.sequence++
+	return "urn:oid:" + g.arc + "." + strconv.FormatUint(g.sequence, 10) + "." + strconv.Itoa(year)
+}
+
+// crockford is the Crockford base 32 alphabet used by SortableGenerator.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// SortableGenerator generates ULIDs: 26 character identifiers that sort in the
+// order they were generated. Each holds a millisecond timestamp followed by 80
+// random bits, which are incremented for identifiers within one millisecond.
+type SortableGenerator struct {
+	clock Clock
+
+	mu      sync.Mutex
+	last    uint64
+	entropy [10]byte
+}
+
+// NewSortableGenerator returns a SortableGenerator reading the time from the
+// clock. If the clock is nil, the SystemClock is used.
+func NewSortableGenerator(clock Clock) *SortableGenerator {
+	if clock == nil {
+		clock = SystemClock
+	}
+	return &SortableGenerator{clock: clock}
+}
+
+// NewIdentifier returns the next identifier.
+func (g *SortableGenerator) NewIdentifier() string {
+	now := g.clock.Now()
+	ms := uint64(now.Unix())*1000 + uint64(now.Nanosecond()/1e6)
+
+	g.mu.Lock()
+	if ms <= g.last {
+		// keep the order within (or behind) the last millisecond
+		ms = g.last
+		for i := len(g.entropy) - 1; i >= 0; i-- {
+			g.entropy[i]++
+			if g.entropy[i] != 0 {
+				break
+			}
+		}
+	} else {
+		random(g.entropy[:])
+	}
+	g.last = ms
+	var id [16]byte
+	binary.BigEndian.PutUint64(id[:8], ms<<16)
+	copy(id[6:], g.entropy[:])
+	g.mu.Unlock()
+
+	// encode the 128 bits as 26 base 32 digits, most significant first
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	var buff [26]byte
+	for i := len(buff) - 1; i >= 0; i-- {
+		buff[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buff[:])
+}
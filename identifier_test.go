@@ -0,0 +1,79 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/thetannerryan/cap"
+)
+
+// TestIdentifierGenerators tests the format of generated identifiers.
+func TestIdentifierGenerators(t *testing.T) {
+	uuid := cap.UUIDGenerator{}.NewIdentifier()
+	test(t, "UUID format", "true", fmt.Sprint(regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(uuid)))
+
+	clock := &stepClock{now: time.Date(2019, 12, 31, 23, 59, 59, 0, time.UTC)}
+	oid := cap.NewOIDGenerator("2.49.0.1.124", clock)
+	oid.Resume(2019, 41)
+	test(t, "OID resumed", "urn:oid:2.49.0.1.124.42.2019", oid.NewIdentifier())
+	clock.now = clock.now.Add(time.Second)
+	test(t, "OID new year", "urn:oid:2.49.0.1.124.1.2020", oid.NewIdentifier())
+	test(t, "OID sequence", "urn:oid:2.49.0.1.124.2.2020", oid.NewIdentifier())
+
+	sortable := cap.NewSortableGenerator(clock)
+	var ids []string
+	for i := 0; i < 100; i++ {
+		if i%10 == 0 {
+			clock.now = clock.now.Add(time.Millisecond)
+		}
+		ids = append(ids, sortable.NewIdentifier())
+	}
+	test(t, "Sortable length", "26", fmt.Sprint(len(ids[0])))
+	test(t, "Sortable prefix", "01DXF6DT01", ids[0][:10])
+	test(t, "Sortable order", "true", fmt.Sprint(sort.StringsAreSorted(ids) && ids[0] != ids[1]))
+}
+
+// stepClock is a Clock that is advanced by the test.
+type stepClock struct {
+	now time.Time
+}
+
+// Now returns the current time of the stepClock.
+func (c *stepClock) Now() time.Time {
+	return c.now
+}
+
+// TestValidateIdentifier tests the character rules for identifiers and
+// senders.
+func TestValidateIdentifier(t *testing.T) {
+	cases := []struct {
+		identifier string
+		err        string
+	}{
+		{"urn:oid:2.49.0.1.124.3936999913.2019", "<nil>"},
+		{"cap-pac@canada.ca", "<nil>"},
+		{"", "Error: empty identifier"},
+		{"KSTO 1055887203", "Error: illegal character ' ' in identifier KSTO 1055887203"},
+		{"a,b", "Error: illegal character ',' in identifier a,b"},
+		{"a<b", "Error: illegal character '<' in identifier a<b"},
+		{"a&b", "Error: illegal character '&' in identifier a&b"},
+	}
+	for _, c := range cases {
+		err := cap.ValidateIdentifier(c.identifier)
+		test(t, "ValidateIdentifier "+c.identifier, c.err, fmt.Sprint(err))
+		if err != nil {
+			test(t, "ValidateIdentifier is "+c.identifier, "true", fmt.Sprint(errors.Is(err, cap.ErrBadIdentifier)))
+		}
+	}
+
+	_, err := cap.NewAlert("cap pac").Build()
+	test(t, "Validate sender", "Error: illegal character ' ' in identifier cap pac at alert/sender", fmt.Sprint(err))
+}
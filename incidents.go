@@ -0,0 +1,158 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// IncidentIndex groups Alerts by the incidents they name. Alerts are keyed on
+// their sender and identifier, so that incidents may be followed across
+// senders. An IncidentIndex is safe for concurrent use.
+type IncidentIndex struct {
+	mu        sync.RWMutex
+	alerts    map[string]*Alert
+	incidents map[string]map[string]bool
+	referrers map[string]map[string]bool
+}
+
+// NewIncidentIndex returns an empty IncidentIndex.
+func NewIncidentIndex() *IncidentIndex {
+	return &IncidentIndex{
+		alerts:    make(map[string]*Alert),
+		incidents: make(map[string]map[string]bool),
+		referrers: make(map[string]map[string]bool),
+	}
+}
+
+// alertKey returns the "sender,identifier" key of an Alert.
+func alertKey(sender, identifier string) string {
+	return sender + "," + identifier
+}
+
+// referenceKey returns the "sender,identifier" key of a "sender,identifier,sent"
+// reference.
+func referenceKey(reference string) string {
+	if i := strings.LastIndexByte(reference, ','); i >= 0 {
+		return reference[:i]
+	}
+	return reference
+}
+
+// addKey adds the key to the set of the name.
+func addKey(sets map[string]map[string]bool, name, key string) {
+	if sets[name] == nil {
+		sets[name] = make(map[string]bool)
+	}
+	sets[name][key] = true
+}
+
+// removeKey removes the key from the set of the name.
+func removeKey(sets map[string]map[string]bool, name, key string) {
+	delete(sets[name], key)
+	if len(sets[name]) == 0 {
+		delete(sets, name)
+	}
+}
+
+// Len returns the number of Alerts within the IncidentIndex.
+func (x *IncidentIndex) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.alerts)
+}
+
+// Insert adds the Alert to the IncidentIndex, replacing any Alert with the same
+// sender and identifier.
+func (x *IncidentIndex) Insert(alert *Alert) {
+	key := alertKey(alert.Sender, alert.Identifier)
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(key)
+	x.alerts[key] = alert
+	for _, incident := range alert.Incidents.Values() {
+		addKey(x.incidents, incident, key)
+	}
+	for _, reference := range alert.References.Values() {
+		addKey(x.referrers, referenceKey(reference), key)
+	}
+}
+
+// Remove deletes the Alert with the given sender and identifier from the
+// IncidentIndex. It reports whether such an Alert was present.
+func (x *IncidentIndex) Remove(sender, identifier string) bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.remove(alertKey(sender, identifier))
+}
+
+// remove deletes the Alert with the key. The write lock must be held.
+func (x *IncidentIndex) remove(key string) bool {
+	alert, ok := x.alerts[key]
+	if !ok {
+		return false
+	}
+	delete(x.alerts, key)
+	for _, incident := range alert.Incidents.Values() {
+		removeKey(x.incidents, incident, key)
+	}
+	for _, reference := range alert.References.Values() {
+		removeKey(x.referrers, referenceKey(reference), key)
+	}
+	return true
+}
+
+// Incidents returns the names of the incidents within the IncidentIndex, in
+// sorted order.
+func (x *IncidentIndex) Incidents() []string {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	names := make([]string, 0, len(x.incidents))
+	for name := range x.incidents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Timeline returns the Alerts of the incident, ordered by their sent time. This
+// includes the Alerts naming the incident, and any updates, cancellations or
+// acknowledgements referring to them that do not repeat the incident.
+func (x *IncidentIndex) Timeline(incident string) []*Alert {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var queue []string
+	for key := range x.incidents[incident] {
+		seen[key] = true
+		queue = append(queue, key)
+	}
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		for referrer := range x.referrers[key] {
+			if !seen[referrer] {
+				seen[referrer] = true
+				queue = append(queue, referrer)
+			}
+		}
+	}
+
+	timeline := make([]*Alert, 0, len(seen))
+	for key := range seen {
+		timeline = append(timeline, x.alerts[key])
+	}
+	sort.Slice(timeline, func(i, j int) bool {
+		a, b := timeline[i], timeline[j]
+		if !a.Sent.val.Equal(b.Sent.val) {
+			return a.Sent.val.Before(b.Sent.val)
+		}
+		return alertKey(a.Sender, a.Identifier) < alertKey(b.Sender, b.Identifier)
+	})
+	return timeline
+}
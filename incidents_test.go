@@ -0,0 +1,74 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thetannerryan/cap"
+)
+
+// build returns the built Alert, failing the test on error.
+func build(t *testing.T, builder *cap.AlertBuilder) *cap.Alert {
+	alert, err := builder.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return alert
+}
+
+// TestIncidents tests parsing incidents with quoted values.
+func TestIncidents(t *testing.T) {
+	var alert cap.Alert
+	data := `<alert xmlns="urn:oasis:names:tc:emergency:cap:1.2"><incidents>"Camp Fire"   KSTO-42
+	river</incidents></alert>`
+	if err := xml.Unmarshal([]byte(data), &alert); err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Incidents", "[Camp Fire|KSTO-42|river]", "["+strings.Join(alert.Incidents.Values(), "|")+"]")
+}
+
+// TestIncidentIndex tests the timeline of an incident across senders.
+func TestIncidentIndex(t *testing.T) {
+	start := time.Date(2019, 1, 9, 2, 0, 0, 0, time.UTC)
+	newAlert := func(sender, identifier string, sent time.Time, incidents ...string) *cap.AlertBuilder {
+		builder := cap.NewAlert(sender).Identifier(identifier).Sent(sent).Incidents(incidents...)
+		builder.AddInfo().Category(cap.CategoryFire).Event("wildfire").
+			Urgency(cap.UrgencyExpected).Severity(cap.SeveritySevere).Certainty(cap.CertaintyLikely)
+		return builder
+	}
+
+	original := build(t, newAlert("county@example.org", "fire-1", start, "Camp Fire"))
+	other := build(t, newAlert("state@example.org", "fire-2", start.Add(time.Hour), "Camp Fire", "flood"))
+	update := build(t, original.NewUpdate().Sent(start.Add(30*time.Minute)).Identifier("fire-1-update"))
+	// a cancellation that does not repeat the incident
+	cancel := build(t, update.NewCancel("contained").Sent(start.Add(2*time.Hour)).Identifier("fire-1-cancel").Incidents())
+	unrelated := build(t, newAlert("county@example.org", "flood-1", start, "flood"))
+
+	index := cap.NewIncidentIndex()
+	for _, alert := range []*cap.Alert{cancel, unrelated, other, update, original} {
+		index.Insert(alert)
+	}
+	test(t, "Incident length", "5", fmt.Sprint(index.Len()))
+	test(t, "Incident names", "[Camp Fire flood]", fmt.Sprint(index.Incidents()))
+
+	timeline := func(incident string) string {
+		var vals []string
+		for _, alert := range index.Timeline(incident) {
+			vals = append(vals, alert.Identifier+" "+alert.MsgType.String())
+		}
+		return strings.Join(vals, ", ")
+	}
+	test(t, "Camp Fire timeline", "fire-1 Alert, fire-1-update Update, fire-2 Alert, fire-1-cancel Cancel", timeline("Camp Fire"))
+	test(t, "flood timeline", "flood-1 Alert, fire-2 Alert", timeline("flood"))
+
+	test(t, "Incident remove", "true", fmt.Sprint(index.Remove("state@example.org", "fire-2")))
+	test(t, "Incident names after remove", "[Camp Fire flood]", fmt.Sprint(index.Incidents()))
+	test(t, "flood timeline after remove", "flood-1 Alert", timeline("flood"))
+}
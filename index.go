@@ -0,0 +1,423 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap
+
+import (
+	"math"
+	"sync"
+)
+
+// Maximum and minimum number of children within an index node.
+const (
+	indexMaxEntries = 16
+	indexMinEntries = indexMaxEntries / 4
+)
+
+// Index is an in-memory R-tree of Alerts, keyed on the bounding boxes of their
+// Areas. Alerts are keyed on their sender and identifier. Areas described only
+// by text or geocodes are not indexed. An Index is safe for concurrent queries
+// alongside a single writer.
+type Index struct {
+	mu      sync.RWMutex
+	root    *indexNode
+	entries map[string][]*indexEntry
+}
+
+// indexRect is a planar rectangle in degrees. BBoxes that cross the
+// antimeridian are stored as two indexRects.
+type indexRect struct {
+	minLon, minLat, maxLon, maxLat float64
+}
+
+// indexEntry is a single Area of an indexed Alert.
+type indexEntry struct {
+	rect    indexRect
+	alert   *Alert
+	polygon []Point
+	circles []Circle
+}
+
+// indexNode is an R-tree node. Leaf nodes hold entries, while branch nodes
+// hold children.
+type indexNode struct {
+	rect     indexRect
+	parent   *indexNode
+	leaf     bool
+	children []*indexNode
+	entries  []*indexEntry
+}
+
+// NewIndex returns an empty Index.
+func NewIndex() *Index {
+	return &Index{
+		root:    &indexNode{leaf: true},
+		entries: make(map[string][]*indexEntry),
+	}
+}
+
+// Len returns the number of Alerts within the Index.
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.entries)
+}
+
+// Insert adds the Alert to the Index, replacing any Alert with the same sender
+// and identifier. If any Area has a malformed polygon or circle, an error is
+// returned and the Index is unchanged.
+func (x *Index) Insert(alert *Alert) error {
+	var entries []*indexEntry
+	for i := range alert.Info {
+		for j := range alert.Info[i].Area {
+			area := &alert.Info[i].Area[j]
+			polygon, err := area.PolygonPoints()
+			if err != nil {
+				return err
+			}
+			circles, err := area.Circles()
+			if err != nil {
+				return err
+			}
+			bbox, err := area.BBox()
+			if err != nil {
+				return err
+			}
+			for _, rect := range bboxRects(bbox) {
+				entries = append(entries, &indexEntry{
+					rect:    rect,
+					alert:   alert,
+					polygon: polygon,
+					circles: circles,
+				})
+			}
+		}
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	key := alertKey(alert.Sender, alert.Identifier)
+	x.remove(key)
+	for _, entry := range entries {
+		x.insert(entry)
+	}
+	x.entries[key] = entries
+	return nil
+}
+
+// Remove deletes the Alert with the given sender and identifier from the
+// Index. It reports whether such an Alert was present.
+func (x *Index) Remove(sender, identifier string) bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.remove(alertKey(sender, identifier))
+}
+
+// QueryPoint returns the Alerts with an Area containing the given latitude and
+// longitude. Candidates found within the tree are refined against the exact
+// polygon and circle geometry.
+func (x *Index) QueryPoint(lat, lon float64) []*Alert {
+	point := Point{Latitude: lat, Longitude: lon}
+	rect := indexRect{minLon: lon, minLat: lat, maxLon: lon, maxLat: lat}
+	seen := make(map[*Alert]bool)
+	var alerts []*Alert
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	x.root.search(rect, func(entry *indexEntry) {
+		if seen[entry.alert] || !entry.contains(point) {
+			return
+		}
+		seen[entry.alert] = true
+		alerts = append(alerts, entry.alert)
+	})
+	return alerts
+}
+
+// QueryBBox returns the Alerts with an Area whose bounding box intersects the
+// given BBox.
+func (x *Index) QueryBBox(bbox BBox) []*Alert {
+	seen := make(map[*Alert]bool)
+	var alerts []*Alert
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	for _, rect := range bboxRects(bbox) {
+		x.root.search(rect, func(entry *indexEntry) {
+			if seen[entry.alert] {
+				return
+			}
+			seen[entry.alert] = true
+			alerts = append(alerts, entry.alert)
+		})
+	}
+	return alerts
+}
+
+// bboxRects splits a BBox into planar indexRects at the antimeridian.
+func bboxRects(bbox BBox) []indexRect {
+	if bbox.Empty() {
+		return nil
+	}
+	if bbox.CrossesAntimeridian() {
+		return []indexRect{
+			{minLon: bbox.MinLon, minLat: bbox.MinLat, maxLon: 180, maxLat: bbox.MaxLat},
+			{minLon: -180, minLat: bbox.MinLat, maxLon: bbox.MaxLon, maxLat: bbox.MaxLat},
+		}
+	}
+	return []indexRect{{minLon: bbox.MinLon, minLat: bbox.MinLat, maxLon: bbox.MaxLon, maxLat: bbox.MaxLat}}
+}
+
+// contains reports whether the Point lies within the exact geometry of the
+// entry.
+func (e *indexEntry) contains(p Point) bool {
+	return shapesContain(e.polygon, e.circles, p)
+}
+
+// union returns the smallest indexRect containing both indexRects.
+func (r indexRect) union(o indexRect) indexRect {
+	return indexRect{
+		minLon: math.Min(r.minLon, o.minLon),
+		minLat: math.Min(r.minLat, o.minLat),
+		maxLon: math.Max(r.maxLon, o.maxLon),
+		maxLat: math.Max(r.maxLat, o.maxLat),
+	}
+}
+
+// intersects reports whether the indexRects overlap.
+func (r indexRect) intersects(o indexRect) bool {
+	return r.minLon <= o.maxLon && o.minLon <= r.maxLon &&
+		r.minLat <= o.maxLat && o.minLat <= r.maxLat
+}
+
+// area returns the planar area of the indexRect.
+func (r indexRect) area() float64 {
+	return (r.maxLon - r.minLon) * (r.maxLat - r.minLat)
+}
+
+// enlargement returns the growth in area required for the indexRect to cover
+// the other.
+func (r indexRect) enlargement(o indexRect) float64 {
+	return r.union(o).area() - r.area()
+}
+
+// search calls fn for every entry beneath the node that intersects the rect.
+func (n *indexNode) search(rect indexRect, fn func(*indexEntry)) {
+	if n.leaf {
+		for _, entry := range n.entries {
+			if entry.rect.intersects(rect) {
+				fn(entry)
+			}
+		}
+		return
+	}
+	for _, child := range n.children {
+		if child.rect.intersects(rect) {
+			child.search(rect, fn)
+		}
+	}
+}
+
+// size returns the number of children or entries within the node.
+func (n *indexNode) size() int {
+	if n.leaf {
+		return len(n.entries)
+	}
+	return len(n.children)
+}
+
+// recalculate recomputes the rect of the node from its contents.
+func (n *indexNode) recalculate() {
+	first := true
+	for _, entry := range n.entries {
+		if first {
+			n.rect, first = entry.rect, false
+		}
+		n.rect = n.rect.union(entry.rect)
+	}
+	for _, child := range n.children {
+		if first {
+			n.rect, first = child.rect, false
+		}
+		n.rect = n.rect.union(child.rect)
+	}
+}
+
+// insert places the entry into a leaf, splitting nodes up the tree as
+// required.
+func (x *Index) insert(entry *indexEntry) {
+	node := x.root
+	for !node.leaf {
+		best := node.children[0]
+		for _, child := range node.children[1:] {
+			grow, bestGrow := child.rect.enlargement(entry.rect), best.rect.enlargement(entry.rect)
+			if grow < bestGrow || (grow == bestGrow && child.rect.area() < best.rect.area()) {
+				best = child
+			}
+		}
+		node = best
+	}
+	node.entries = append(node.entries, entry)
+
+	for node != nil {
+		var sibling *indexNode
+		if node.size() > indexMaxEntries {
+			sibling = node.split()
+		} else {
+			node.recalculate()
+		}
+		if sibling != nil {
+			if node.parent == nil {
+				x.root = &indexNode{children: []*indexNode{node, sibling}}
+				node.parent, sibling.parent = x.root, x.root
+				x.root.recalculate()
+				return
+			}
+			sibling.parent = node.parent
+			node.parent.children = append(node.parent.children, sibling)
+		}
+		node = node.parent
+	}
+}
+
+// split moves roughly half of the contents of an overflowing node into a new
+// sibling, using Guttman's quadratic split.
+func (n *indexNode) split() *indexNode {
+	rects := make([]indexRect, n.size())
+	for i := range rects {
+		if n.leaf {
+			rects[i] = n.entries[i].rect
+		} else {
+			rects[i] = n.children[i].rect
+		}
+	}
+
+	// pick the two seeds that would waste the most area together
+	seedA, seedB, worst := 0, 1, math.Inf(-1)
+	for i := range rects {
+		for j := i + 1; j < len(rects); j++ {
+			waste := rects[i].union(rects[j]).area() - rects[i].area() - rects[j].area()
+			if waste > worst {
+				seedA, seedB, worst = i, j, waste
+			}
+		}
+	}
+
+	groupA, groupB := []int{seedA}, []int{seedB}
+	rectA, rectB := rects[seedA], rects[seedB]
+	for i := range rects {
+		if i == seedA || i == seedB {
+			continue
+		}
+		remaining := len(rects) - len(groupA) - len(groupB)
+		switch {
+		case len(groupA)+remaining <= indexMinEntries:
+			groupA, rectA = append(groupA, i), rectA.union(rects[i])
+		case len(groupB)+remaining <= indexMinEntries:
+			groupB, rectB = append(groupB, i), rectB.union(rects[i])
+		case rectA.enlargement(rects[i]) <= rectB.enlargement(rects[i]):
+			groupA, rectA = append(groupA, i), rectA.union(rects[i])
+		default:
+			groupB, rectB = append(groupB, i), rectB.union(rects[i])
+		}
+	}
+
+	sibling := &indexNode{leaf: n.leaf}
+	if n.leaf {
+		entries := n.entries
+		n.entries = nil
+		for _, i := range groupA {
+			n.entries = append(n.entries, entries[i])
+		}
+		for _, i := range groupB {
+			sibling.entries = append(sibling.entries, entries[i])
+		}
+	} else {
+		children := n.children
+		n.children = nil
+		for _, i := range groupA {
+			n.children = append(n.children, children[i])
+		}
+		for _, i := range groupB {
+			children[i].parent = sibling
+			sibling.children = append(sibling.children, children[i])
+		}
+	}
+	n.recalculate()
+	sibling.recalculate()
+	return sibling
+}
+
+// remove deletes every entry of the "sender,identifier" key from the tree.
+// Nodes left empty are pruned, but underfull nodes are not rebalanced.
+func (x *Index) remove(key string) bool {
+	entries, ok := x.entries[key]
+	if !ok {
+		return false
+	}
+	for _, entry := range entries {
+		leaf := x.root.findLeaf(entry)
+		if leaf == nil {
+			continue
+		}
+		for i := range leaf.entries {
+			if leaf.entries[i] == entry {
+				leaf.entries = append(leaf.entries[:i], leaf.entries[i+1:]...)
+				break
+			}
+		}
+		x.condense(leaf)
+	}
+	delete(x.entries, key)
+	return true
+}
+
+// findLeaf returns the leaf beneath the node holding the entry.
+func (n *indexNode) findLeaf(entry *indexEntry) *indexNode {
+	if n.leaf {
+		for _, e := range n.entries {
+			if e == entry {
+				return n
+			}
+		}
+		return nil
+	}
+	for _, child := range n.children {
+		if child.rect.intersects(entry.rect) {
+			if leaf := child.findLeaf(entry); leaf != nil {
+				return leaf
+			}
+		}
+	}
+	return nil
+}
+
+// condense walks from a node to the root, pruning empty nodes and shrinking
+// rects.
+func (x *Index) condense(node *indexNode) {
+	for node.parent != nil {
+		parent := node.parent
+		if node.size() == 0 {
+			for i := range parent.children {
+				if parent.children[i] == node {
+					parent.children = append(parent.children[:i], parent.children[i+1:]...)
+					break
+				}
+			}
+		} else {
+			node.recalculate()
+		}
+		node = parent
+	}
+	if node.size() == 0 {
+		x.root = &indexNode{leaf: true}
+		return
+	}
+	node.recalculate()
+	// collapse a root with a single child
+	for !x.root.leaf && len(x.root.children) == 1 {
+		x.root = x.root.children[0]
+		x.root.parent = nil
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/thetannerryan/cap"
+)
+
+// identifiers returns the sorted identifiers of the Alerts.
+func identifiers(alerts []*cap.Alert) string {
+	ids := make([]string, len(alerts))
+	for i, alert := range alerts {
+		ids[i] = alert.Identifier
+	}
+	sort.Strings(ids)
+	return fmt.Sprint(ids)
+}
+
+// syntheticAlert returns an Alert with a single square polygon of the given
+// size, in degrees, anchored at the given corner.
+func syntheticAlert(id string, lat, lon, size float64) *cap.Alert {
+	polygon := fmt.Sprintf("%g,%g %g,%g %g,%g %g,%g %g,%g",
+		lat, lon, lat, lon+size, lat+size, lon+size, lat+size, lon, lat, lon)
+	alert, err := cap.ParseCAP([]byte(`<alert xmlns="urn:oasis:names:tc:emergency:cap:1.2">
+		<identifier>` + id + `</identifier><info><area><polygon>` + polygon + `</polygon></area></info></alert>`))
+	if err != nil {
+		panic(err)
+	}
+	return alert
+}
+
+// TestIndex tests insertion, exact point queries, bounding box queries and
+// removal against the OASIS and NAADS examples.
+func TestIndex(t *testing.T) {
+	index := cap.NewIndex()
+	for _, name := range []string{
+		"Oasis_AmberAlert.xml",
+		"Oasis_EarthquakeReport.xml",
+		"Oasis_HomelandAlert.xml",
+		"Oasis_ThunderstormWarning.xml",
+		"PelmorexNAADS_WindWarning.xml",
+	} {
+		contents, err := ioutil.ReadFile("testing/" + name)
+		if err != nil {
+			panic(err)
+		}
+		alert, err := cap.ParseCAP(contents)
+		if err != nil {
+			panic(err)
+		}
+		if err := index.Insert(alert); err != nil {
+			t.Fatal(err)
+		}
+	}
+	test(t, "Index length", "5", strconv.Itoa(index.Len()))
+
+	// inside the thunderstorm polygon, and inside its bbox but not the polygon
+	test(t, "Index point inside", "[KSTO1055887203]", identifiers(index.QueryPoint(38.48, -119.93)))
+	test(t, "Index point bbox corner", "[]", identifiers(index.QueryPoint(38.61, -120.13)))
+	test(t, "Index point earthquake", "[TRI13970876.2]", identifiers(index.QueryPoint(32.9525, -115.5527)))
+	test(t, "Index point wind", "[urn:oid:2.49.0.1.124.3936999913.2019]", identifiers(index.QueryPoint(47.5, -61.8)))
+
+	// the same identifier from another sender is a different alert
+	other := syntheticAlert("KSTO1055887203", 10, 10, 1)
+	other.Sender = "other@example.org"
+	if err := index.Insert(other); err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Index other sender length", "6", strconv.Itoa(index.Len()))
+	test(t, "Index other sender point", "[KSTO1055887203]", identifiers(index.QueryPoint(38.48, -119.93)))
+	test(t, "Index other sender remove", "true", fmt.Sprint(index.Remove("other@example.org", "KSTO1055887203")))
+
+	bbox := cap.BBox{MinLat: 30, MinLon: -125, MaxLat: 40, MaxLon: -110}
+	test(t, "Index bbox", "[KSTO1055887203 TRI13970876.2]", identifiers(index.QueryBBox(bbox)))
+
+	test(t, "Index remove", "true", fmt.Sprint(index.Remove("KSTO@NWS.NOAA.GOV", "KSTO1055887203")))
+	test(t, "Index remove missing", "false", fmt.Sprint(index.Remove("KSTO@NWS.NOAA.GOV", "KSTO1055887203")))
+	test(t, "Index bbox after remove", "[TRI13970876.2]", identifiers(index.QueryBBox(bbox)))
+}
+
+// TestIndexMany tests the Index against a brute force scan once nodes have
+// been split and removed.
+func TestIndexMany(t *testing.T) {
+	index := cap.NewIndex()
+	rng := rand.New(rand.NewSource(1))
+	var alerts []*cap.Alert
+	for i := 0; i < 2000; i++ {
+		alert := syntheticAlert(strconv.Itoa(i), rng.Float64()*160-80, rng.Float64()*350-175, rng.Float64()*2+0.01)
+		alerts = append(alerts, alert)
+		if err := index.Insert(alert); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 2000; i += 3 {
+		index.Remove("", strconv.Itoa(i))
+	}
+	for q := 0; q < 200; q++ {
+		lat, lon := rng.Float64()*160-80, rng.Float64()*350-175
+		var expected []*cap.Alert
+		for i, alert := range alerts {
+			if i%3 == 0 {
+				continue
+			}
+			if ok, _ := alert.Info[0].Area[0].Contains(cap.Point{Latitude: lat, Longitude: lon}); ok {
+				expected = append(expected, alert)
+			}
+		}
+		if identifiers(expected) != identifiers(index.QueryPoint(lat, lon)) {
+			t.Fatalf("Incorrect output for %g,%g", lat, lon)
+		}
+	}
+}
+
+// BenchmarkIndexInsert measures insertion of single-polygon Alerts.
+func BenchmarkIndexInsert(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	alerts := make([]*cap.Alert, b.N)
+	for i := range alerts {
+		alerts[i] = syntheticAlert(strconv.Itoa(i), rng.Float64()*160-80, rng.Float64()*350-175, 1)
+	}
+	index := cap.NewIndex()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index.Insert(alerts[i])
+	}
+}
+
+// BenchmarkIndexQueryPoint measures point queries against 10,000 Alerts.
+func BenchmarkIndexQueryPoint(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	index := cap.NewIndex()
+	for i := 0; i < 10000; i++ {
+		index.Insert(syntheticAlert(strconv.Itoa(i), rng.Float64()*160-80, rng.Float64()*350-175, 1))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		index.QueryPoint(rng.Float64()*160-80, rng.Float64()*350-175)
+	}
+}
@@ -0,0 +1,451 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// capNamespace is the namespace of CAP 1.2 elements.
+const capNamespace = "urn:oasis:names:tc:emergency:cap:1.2"
+
+// dsigNamespace is the namespace of XML digital signature elements.
+const dsigNamespace = "http://www.w3.org/2000/09/xmldsig#"
+
+// jsonAlert is the JSON form of an Alert, described by schema/alert.schema.json.
+type jsonAlert struct {
+	Identifier  string          `json:"identifier"`
+	Sender      string          `json:"sender"`
+	Sent        DateTime        `json:"sent"`
+	Status      Status          `json:"status"`
+	MsgType     MsgType         `json:"msgType"`
+	Source      string          `json:"source,omitempty"`
+	Scope       Scope           `json:"scope"`
+	Restriction string          `json:"restriction,omitempty"`
+	Addresses   []string        `json:"addresses,omitempty"`
+	Code        []string        `json:"code,omitempty"`
+	Note        string          `json:"note,omitempty"`
+	References  []string        `json:"references,omitempty"`
+	Incidents   []string        `json:"incidents,omitempty"`
+	Info        []jsonInfo      `json:"info,omitempty"`
+	Signature   []jsonSignature `json:"signature,omitempty"`
+}
+
+// jsonInfo is the JSON form of an Info.
+type jsonInfo struct {
+	Language     string         `json:"language,omitempty"`
+	Category     []Category     `json:"category"`
+	Event        string         `json:"event"`
+	ResponseType []ResponseType `json:"responseType,omitempty"`
+	Urgency      Urgency        `json:"urgency"`
+	Severity     Severity       `json:"severity"`
+	Certainty    Certainty      `json:"certainty"`
+	Audience     string         `json:"audience,omitempty"`
+	EventCode    []KeyValue     `json:"eventCode,omitempty"`
+	Effective    *DateTime      `json:"effective,omitempty"`
+	Onset        *DateTime      `json:"onset,omitempty"`
+	Expires      *DateTime      `json:"expires,omitempty"`
+	SenderName   string         `json:"senderName,omitempty"`
+	Headline     string         `json:"headline,omitempty"`
+	Description  string         `json:"description,omitempty"`
+	Instruction  string         `json:"instruction,omitempty"`
+	Web          string         `json:"web,omitempty"`
+	Contact      string         `json:"contact,omitempty"`
+	Parameter    []KeyValue     `json:"parameter,omitempty"`
+	Resource     []jsonResource `json:"resource,omitempty"`
+	Area         []jsonArea     `json:"area,omitempty"`
+}
+
+// jsonResource is the JSON form of a Resource.
+type jsonResource struct {
+	ResourceDesc string `json:"resourceDesc"`
+	MimeType     string `json:"mimeType"`
+	Size         int    `json:"size,omitempty"`
+	URI          string `json:"uri,omitempty"`
+	DerefURI     string `json:"derefUri,omitempty"`
+	Digest       string `json:"digest,omitempty"`
+}
+
+// jsonArea is the JSON form of an Area. Coordinates are JSON numbers, keeping
+// the text of the original values.
+type jsonArea struct {
+	AreaDesc string           `json:"areaDesc"`
+	Polygon  [][2]json.Number `json:"polygon,omitempty"`
+	Circle   []jsonCircle     `json:"circle,omitempty"`
+	Geocode  []KeyValue       `json:"geocode,omitempty"`
+	Altitude float32          `json:"altitude,omitempty"`
+	Ceiling  float32          `json:"ceiling,omitempty"`
+}
+
+// jsonCircle is the JSON form of a circle, as a [latitude, longitude] center and
+// a radius in kilometers.
+type jsonCircle struct {
+	Center [2]json.Number `json:"center"`
+	Radius json.Number    `json:"radius"`
+}
+
+// jsonSignature is the JSON form of a Signature. Algorithms are given by their
+// URIs.
+type jsonSignature struct {
+	ID                  string                  `json:"id,omitempty"`
+	SignedInfo          jsonSignedInfo          `json:"signedInfo"`
+	SignatureValue      string                  `json:"signatureValue,omitempty"`
+	X509Certificate     string                  `json:"x509Certificate,omitempty"`
+	SignatureProperties []jsonSignatureProperty `json:"signatureProperties,omitempty"`
+}
+
+// jsonSignedInfo is the JSON form of a SignedInfo.
+type jsonSignedInfo struct {
+	CanonicalizationMethod string        `json:"canonicalizationMethod,omitempty"`
+	SignatureMethod        string        `json:"signatureMethod,omitempty"`
+	Reference              jsonReference `json:"reference"`
+}
+
+// jsonReference is the JSON form of a Reference.
+type jsonReference struct {
+	URI          string `json:"uri,omitempty"`
+	Transform    string `json:"transform,omitempty"`
+	DigestMethod string `json:"digestMethod,omitempty"`
+	DigestValue  string `json:"digestValue,omitempty"`
+}
+
+// jsonSignatureProperty is the JSON form of a SignatureProperty.
+type jsonSignatureProperty struct {
+	ID     string `json:"id,omitempty"`
+	Target string `json:"target,omitempty"`
+	XC     string `json:"xc,omitempty"`
+}
+
+// jsonNumberPattern matches the numbers allowed by JSON.
+var jsonNumberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// toNumber returns a coordinate as a JSON number. The text is kept unless JSON
+// does not allow it, such as "+45.0" or "045".
+func toNumber(val string) (json.Number, error) {
+	num, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return "", errors.New("Error: illegal coordinate " + val)
+	}
+	if jsonNumberPattern.MatchString(val) {
+		return json.Number(val), nil
+	}
+	return json.Number(strconv.FormatFloat(num, 'f', -1, 64)), nil
+}
+
+// toPair returns a "latitude,longitude" point as a pair of JSON numbers.
+func toPair(val string) ([2]json.Number, error) {
+	var pair [2]json.Number
+	if _, err := parsePoint(val); err != nil {
+		return pair, err
+	}
+	coords := strings.Split(val, ",")
+	for i := range pair {
+		num, err := toNumber(strings.TrimSpace(coords[i]))
+		if err != nil {
+			return pair, err
+		}
+		pair[i] = num
+	}
+	return pair, nil
+}
+
+// fromPair returns a pair of JSON numbers as a "latitude,longitude" point.
+func fromPair(pair [2]json.Number) string {
+	return pair[0].String() + "," + pair[1].String()
+}
+
+// checkCodes returns an error for the first of the codes that is not defined by
+// CAP 1.2, such as a code kept by lenient parsing, as the JSON form cannot
+// represent it. The texts are those of the Alert or Info holding the codes.
+func checkCodes(texts []string, codes ...Code) error {
+	for _, code := range codes {
+		if code.validate() == nil {
+			continue
+		}
+		text, ok := keptText(texts, code)
+		if !ok {
+			text = code.String()
+		}
+		return illegalValue(text, reflect.TypeOf(code).Name())
+	}
+	return nil
+}
+
+// optionalTime returns a pointer to the DateTime, or nil if it is unset.
+func optionalTime(t DateTime) *DateTime {
+	if t.val.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// requiredTime returns the DateTime, or an unset DateTime for nil.
+func requiredTime(t *DateTime) DateTime {
+	if t == nil {
+		return DateTime{}
+	}
+	return *t
+}
+
+// MarshalJSON returns the JSON form of the Alert, as described by
+// schema/alert.schema.json. Lists are arrays, unset elements are omitted, and
+// polygons and circles are parsed into coordinates. If the Alert has a code,
+// dateTime or coordinate that cannot be represented, such as a code kept by
+// lenient parsing, an error is returned.
+//
+// The JSON form is an interchange format, unlike json.Marshal of the Alert
+// struct, and UnmarshalJSON returns an Alert that marshals to the same XML.
+func MarshalJSON(alert *Alert) ([]byte, error) {
+	if err := checkCodes(alert.Unrecognized, alert.Status, alert.MsgType, alert.Scope); err != nil {
+		return nil, err
+	}
+	doc := jsonAlert{
+		Identifier:  alert.Identifier,
+		Sender:      alert.Sender,
+		Sent:        alert.Sent,
+		Status:      alert.Status,
+		MsgType:     alert.MsgType,
+		Source:      alert.Source,
+		Scope:       alert.Scope,
+		Restriction: alert.Restriction,
+		Addresses:   alert.Addresses.Values(),
+		Code:        alert.Code,
+		Note:        alert.Note,
+		References:  alert.References.Values(),
+		Incidents:   alert.Incidents.Values(),
+	}
+	for i := range alert.Info {
+		info, err := toJSONInfo(&alert.Info[i])
+		if err != nil {
+			return nil, err
+		}
+		doc.Info = append(doc.Info, info)
+	}
+	for _, sig := range alert.Signature {
+		out := jsonSignature{
+			ID: sig.ID,
+			SignedInfo: jsonSignedInfo{
+				CanonicalizationMethod: sig.SignedInfo.CanonicalizationMethod.Algorithm,
+				SignatureMethod:        sig.SignedInfo.SignatureMethod.Algorithm,
+				Reference: jsonReference{
+					URI:          sig.SignedInfo.Reference.URI,
+					Transform:    sig.SignedInfo.Reference.Transform.Algorithm,
+					DigestMethod: sig.SignedInfo.Reference.DigestMethod.Algorithm,
+					DigestValue:  sig.SignedInfo.Reference.DigestValue,
+				},
+			},
+			SignatureValue:  sig.SignatureValue,
+			X509Certificate: sig.X509Certificate,
+		}
+		for _, prop := range sig.SignatureProperties {
+			out.SignatureProperties = append(out.SignatureProperties, jsonSignatureProperty{
+				ID:     prop.ID,
+				Target: prop.Target,
+				XC:     prop.XCValue.XC,
+			})
+		}
+		doc.Signature = append(doc.Signature, out)
+	}
+	return json.Marshal(doc)
+}
+
+// toJSONInfo returns the JSON form of the Info.
+func toJSONInfo(info *Info) (jsonInfo, error) {
+	var codes []Code
+	for _, category := range info.Category {
+		codes = append(codes, category)
+	}
+	for _, responseType := range info.ResponseType {
+		codes = append(codes, responseType)
+	}
+	codes = append(codes, info.Urgency, info.Severity, info.Certainty)
+	if err := checkCodes(info.Unrecognized, codes...); err != nil {
+		return jsonInfo{}, err
+	}
+	out := jsonInfo{
+		Language:     info.Language,
+		Category:     info.Category,
+		Event:        info.Event,
+		ResponseType: info.ResponseType,
+		Urgency:      info.Urgency,
+		Severity:     info.Severity,
+		Certainty:    info.Certainty,
+		Audience:     info.Audience,
+		EventCode:    info.EventCode,
+		Effective:    optionalTime(info.Effective),
+		Onset:        optionalTime(info.Onset),
+		Expires:      optionalTime(info.Expires),
+		SenderName:   info.SenderName,
+		Headline:     info.Headline,
+		Description:  info.Description,
+		Instruction:  info.Instruction,
+		Web:          info.Web,
+		Contact:      info.Contact,
+		Parameter:    info.Parameter,
+	}
+	for _, res := range info.Resource {
+		out.Resource = append(out.Resource, jsonResource{
+			ResourceDesc: res.ResourceDesc,
+			MimeType:     res.MimeType,
+			Size:         res.Size,
+			URI:          res.URI,
+			DerefURI:     res.DerefURI,
+			Digest:       res.Digest,
+		})
+	}
+	for i := range info.Area {
+		area := &info.Area[i]
+		outArea := jsonArea{
+			AreaDesc: area.AreaDesc,
+			Geocode:  area.Geocode,
+			Altitude: area.Altitude,
+			Ceiling:  area.Ceiling,
+		}
+		for _, point := range area.Polygon.Values() {
+			pair, err := toPair(point)
+			if err != nil {
+				return out, err
+			}
+			outArea.Polygon = append(outArea.Polygon, pair)
+		}
+		for _, circle := range area.Circle {
+			if strings.TrimSpace(circle) == "" {
+				continue
+			}
+			if _, err := parseCircle(circle); err != nil {
+				return out, err
+			}
+			fields := strings.Fields(circle)
+			center, err := toPair(fields[0])
+			if err != nil {
+				return out, err
+			}
+			radius, err := toNumber(fields[1])
+			if err != nil {
+				return out, err
+			}
+			outArea.Circle = append(outArea.Circle, jsonCircle{Center: center, Radius: radius})
+		}
+		out.Area = append(out.Area, outArea)
+	}
+	return out, nil
+}
+
+// UnmarshalJSON returns the Alert of the JSON form written by MarshalJSON. Codes
+// and dateTime values are checked as strictly as by ParseCAP.
+func UnmarshalJSON(data []byte) (*Alert, error) {
+	var doc jsonAlert
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	alert := &Alert{
+		XMLName:     xml.Name{Space: capNamespace, Local: "alert"},
+		Identifier:  doc.Identifier,
+		Sender:      doc.Sender,
+		Sent:        doc.Sent,
+		Status:      doc.Status,
+		MsgType:     doc.MsgType,
+		Source:      doc.Source,
+		Scope:       doc.Scope,
+		Restriction: doc.Restriction,
+		Addresses:   NewList(doc.Addresses...),
+		Code:        doc.Code,
+		Note:        doc.Note,
+		References:  NewList(doc.References...),
+		Incidents:   NewList(doc.Incidents...),
+	}
+	for _, info := range doc.Info {
+		alert.Info = append(alert.Info, fromJSONInfo(info))
+	}
+	for _, sig := range doc.Signature {
+		out := Signature{
+			XMLName: xml.Name{Space: dsigNamespace, Local: "Signature"},
+			ID:      sig.ID,
+			SignedInfo: SignedInfo{
+				CanonicalizationMethod: Algorithm{sig.SignedInfo.CanonicalizationMethod},
+				SignatureMethod:        Algorithm{sig.SignedInfo.SignatureMethod},
+				Reference: Reference{
+					URI:          sig.SignedInfo.Reference.URI,
+					Transform:    Algorithm{sig.SignedInfo.Reference.Transform},
+					DigestMethod: Algorithm{sig.SignedInfo.Reference.DigestMethod},
+					DigestValue:  sig.SignedInfo.Reference.DigestValue,
+				},
+			},
+			SignatureValue:  sig.SignatureValue,
+			X509Certificate: sig.X509Certificate,
+		}
+		for _, prop := range sig.SignatureProperties {
+			out.SignatureProperties = append(out.SignatureProperties, SignatureProperty{
+				ID:      prop.ID,
+				Target:  prop.Target,
+				XCValue: XCValue{prop.XC},
+			})
+		}
+		alert.Signature = append(alert.Signature, out)
+	}
+	return alert, nil
+}
+
+// fromJSONInfo returns the Info of the JSON form.
+func fromJSONInfo(info jsonInfo) Info {
+	out := Info{
+		XMLName:      xml.Name{Space: capNamespace, Local: "info"},
+		Language:     info.Language,
+		Category:     info.Category,
+		Event:        info.Event,
+		ResponseType: info.ResponseType,
+		Urgency:      info.Urgency,
+		Severity:     info.Severity,
+		Certainty:    info.Certainty,
+		Audience:     info.Audience,
+		EventCode:    info.EventCode,
+		Effective:    requiredTime(info.Effective),
+		Onset:        requiredTime(info.Onset),
+		Expires:      requiredTime(info.Expires),
+		SenderName:   info.SenderName,
+		Headline:     info.Headline,
+		Description:  info.Description,
+		Instruction:  info.Instruction,
+		Web:          info.Web,
+		Contact:      info.Contact,
+		Parameter:    info.Parameter,
+	}
+	for _, res := range info.Resource {
+		out.Resource = append(out.Resource, Resource{
+			XMLName:      xml.Name{Space: capNamespace, Local: "resource"},
+			ResourceDesc: res.ResourceDesc,
+			MimeType:     res.MimeType,
+			Size:         res.Size,
+			URI:          res.URI,
+			DerefURI:     res.DerefURI,
+			Digest:       res.Digest,
+		})
+	}
+	for _, area := range info.Area {
+		outArea := Area{
+			XMLName:  xml.Name{Space: capNamespace, Local: "area"},
+			AreaDesc: area.AreaDesc,
+			Geocode:  area.Geocode,
+			Altitude: area.Altitude,
+			Ceiling:  area.Ceiling,
+		}
+		var points []string
+		for _, pair := range area.Polygon {
+			points = append(points, fromPair(pair))
+		}
+		outArea.Polygon = NewList(points...)
+		for _, circle := range area.Circle {
+			outArea.Circle = append(outArea.Circle, fromPair(circle.Center)+" "+circle.Radius.String())
+		}
+		out.Area = append(out.Area, outArea)
+	}
+	return out
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/thetannerryan/cap"
+)
+
+// schemaKeys adds the property names used anywhere within the JSON schema to
+// the set.
+func schemaKeys(schema interface{}, keys map[string]bool) {
+	switch schema := schema.(type) {
+	case map[string]interface{}:
+		for key, val := range schema {
+			if props, ok := val.(map[string]interface{}); ok && key == "properties" {
+				for name := range props {
+					keys[name] = true
+				}
+			}
+			schemaKeys(val, keys)
+		}
+	case []interface{}:
+		for _, val := range schema {
+			schemaKeys(val, keys)
+		}
+	}
+}
+
+// documentKeys adds the object keys of the JSON document to the set.
+func documentKeys(doc interface{}, keys map[string]bool) {
+	switch doc := doc.(type) {
+	case map[string]interface{}:
+		for key, val := range doc {
+			keys[key] = true
+			documentKeys(val, keys)
+		}
+	case []interface{}:
+		for _, val := range doc {
+			documentKeys(val, keys)
+		}
+	}
+}
+
+// capElements returns each element of the CAP namespace within the document
+// that has text, with its path, skipping empty elements and the digital
+// signatures.
+func capElements(data []byte) []string {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var elements, path []string
+	var text string
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return elements
+		}
+		if err != nil {
+			panic(err)
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if token.Name.Space != "urn:oasis:names:tc:emergency:cap:1.2" {
+				decoder.Skip()
+				continue
+			}
+			path = append(path, token.Name.Local)
+			text = ""
+		case xml.CharData:
+			text += string(token)
+		case xml.EndElement:
+			if text := strings.Join(strings.Fields(text), " "); text != "" {
+				elements = append(elements, strings.Join(path, "/")+"="+text)
+			}
+			path = path[:len(path)-1]
+			text = ""
+		}
+	}
+}
+
+// TestJSON tests that the JSON form of each example alert marshals back to the
+// same XML as the original document, and uses only the names of the schema.
+func TestJSON(t *testing.T) {
+	contents, err := ioutil.ReadFile("schema/alert.schema.json")
+	if err != nil {
+		panic(err)
+	}
+	var schema interface{}
+	if err := json.Unmarshal(contents, &schema); err != nil {
+		t.Fatal(err)
+	}
+	allowed := make(map[string]bool)
+	schemaKeys(schema, allowed)
+
+	for _, name := range []string{
+		"Oasis_AmberAlert.xml",
+		"Oasis_EarthquakeReport.xml",
+		"Oasis_HomelandAlert.xml",
+		"Oasis_ThunderstormWarning.xml",
+		"PelmorexNAADS_WindWarning.xml",
+	} {
+		contents, err := ioutil.ReadFile("testing/" + name)
+		if err != nil {
+			panic(err)
+		}
+		alert, err := cap.ParseCAP(contents)
+		if err != nil {
+			panic(err)
+		}
+		data, err := cap.MarshalJSON(alert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := cap.UnmarshalJSON(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, _ := xml.Marshal(alert)
+		actual, _ := xml.Marshal(parsed)
+		test(t, "JSON round trip "+name, string(expected), string(actual))
+		test(t, "JSON round trip elements "+name, strings.Join(capElements(contents), "\n"), strings.Join(capElements(actual), "\n"))
+		again, err := cap.MarshalJSON(parsed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		test(t, "JSON stable "+name, string(data), string(again))
+
+		var doc interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatal(err)
+		}
+		used := make(map[string]bool)
+		documentKeys(doc, used)
+		var unknown []string
+		for key := range used {
+			if !allowed[key] {
+				unknown = append(unknown, key)
+			}
+		}
+		sort.Strings(unknown)
+		test(t, "JSON schema names "+name, "[]", fmt.Sprint(unknown))
+	}
+}
+
+// TestJSONForm tests the shape of the JSON form.
+func TestJSONForm(t *testing.T) {
+	contents, err := ioutil.ReadFile("testing/Oasis_ThunderstormWarning.xml")
+	if err != nil {
+		panic(err)
+	}
+	alert, err := cap.ParseCAP(contents)
+	if err != nil {
+		panic(err)
+	}
+	alert.Addresses = cap.NewList("ops@example.org", "Duty Officer")
+	alert.Info[0].Area[0].Circle = []string{"+38.50,-120.0 10"}
+	data, err := cap.MarshalJSON(alert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Addresses []string
+		Info      []map[string]json.RawMessage
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	test(t, "JSON addresses", "[ops@example.org Duty Officer]", fmt.Sprint(doc.Addresses))
+	var keys []string
+	for key := range doc.Info[0] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	test(t, "JSON info keys", "[area category certainty contact description event eventCode expires headline instruction responseType senderName severity urgency]", fmt.Sprint(keys))
+	var areas []struct {
+		Polygon json.RawMessage
+		Circle  json.RawMessage
+	}
+	if err := json.Unmarshal(doc.Info[0]["area"], &areas); err != nil {
+		t.Fatal(err)
+	}
+	test(t, "JSON polygon", "[[38.47,-120.14],[38.34,-119.95],[38.52,-119.74],[38.62,-119.89],[38.47,-120.14]]", string(areas[0].Polygon))
+	test(t, "JSON circle", `[{"center":[38.5,-120.0],"radius":10}]`, string(areas[0].Circle))
+
+	alert.Info[0].Area[0].Circle = []string{"38.5,-120.0"}
+	_, err = cap.MarshalJSON(alert)
+	test(t, "JSON bad circle", "Error: illegal value 38.5,-120.0 for Circle", fmt.Sprint(err))
+
+	_, err = cap.UnmarshalJSON([]byte(`{"status":"Unreal"}`))
+	test(t, "JSON bad status", "true", fmt.Sprint(strings.Contains(fmt.Sprint(err), "Unreal")))
+
+	// codes kept by lenient parsing are outside the schema, and are rejected
+	// rather than written as text that cannot be read back
+	lenient, _, err := cap.ParseCAPWithOptions(deviantAlert, cap.ParseOptions{Lenient: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = cap.MarshalJSON(lenient)
+	test(t, "JSON kept code", "Error: illegal value Weather for Category code", fmt.Sprint(err))
+
+	// the struct tags of the model omit the XML names
+	data, err = json.Marshal(alert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "JSON struct XMLName", "false", fmt.Sprint(strings.Contains(string(data), "Local")))
+
+	// the digital signatures are kept
+	contents, err = ioutil.ReadFile("testing/PelmorexNAADS_WindWarning.xml")
+	if err != nil {
+		panic(err)
+	}
+	signed, err := cap.ParseCAP(contents)
+	if err != nil {
+		panic(err)
+	}
+	data, err = json.Marshal(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var model struct {
+		Signature []struct {
+			SignatureValue string
+		}
+	}
+	if err := json.Unmarshal(data, &model); err != nil {
+		t.Fatal(err)
+	}
+	test(t, "JSON struct signatures", fmt.Sprint(len(signed.Signature)), fmt.Sprint(len(model.Signature)))
+	for i, sig := range model.Signature {
+		test(t, "JSON struct signature value", signed.Signature[i].SignatureValue, sig.SignatureValue)
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// ParseOptions configures the behaviour of ParseCAPWithOptions.
+type ParseOptions struct {
+	// Lenient keeps code values that are not defined by CAP 1.2, such as
+	// <category>Weather</category>, rather than failing the parse. Codes that
+	// differ only by case or surrounding whitespace are matched to their
+	// defined value. Each deviation is reported as a Warning.
+	Lenient bool
+	// TolerantDateTime accepts dateTime values in common variants of the CAP
+	// format, such as a Z suffix, a missing seconds field or an offset without
+	// a colon. Each deviation is reported as a Warning. Values are re-marshaled
+	// in the CAP format.
+	TolerantDateTime bool
+}
+
+// Warning describes a deviation from CAP 1.2 that was tolerated by lenient or
+// tolerant parsing.
+type Warning struct {
+	Path    string // Slash separated element path, such as alert/info[2]/urgency
+	Line    int    // Line of the offending element, starting at 1
+	Element string // Name of the offending element
+	Value   string // Original text of the offending element
+	Message string // Description of how the value was handled
+
+	offset int64
+}
+
+// String returns a human readable form of the Warning.
+func (w Warning) String() string {
+	return w.Path + ": " + w.Message + " " + strconv.Quote(w.Value)
+}
+
+// parseState is the configuration and collected warnings of a single parse.
+type parseState struct {
+	opts     ParseOptions
+	warnings []Warning
+	kept     []string // Text of the codes kept so far, referenced by negative codes
+}
+
+// parseStates maps each in-progress *xml.Decoder to its *parseState, allowing
+// UnmarshalXML methods to observe the options of the parse they belong to.
+var parseStates sync.Map
+
+// ParseCAPWithOptions takes an XML byte CAP 1.2 message and returns an Alert,
+// parsed according to the options. Any tolerated deviations are returned as
+// warnings. If the message is invalid, a *ParseError will be returned.
+func ParseCAPWithOptions(data []byte, opts ParseOptions) (*Alert, []Warning, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	state := &parseState{opts: opts}
+	parseStates.Store(decoder, state)
+	defer parseStates.Delete(decoder)
+
+	var alert Alert
+	err := decoder.Decode(&alert)
+	for i := range state.warnings {
+		loc := locate(data, state.warnings[i].offset, true)
+		state.warnings[i].Path, state.warnings[i].Line = loc.path, loc.line
+	}
+	if err != nil {
+		return nil, state.warnings, parseError(data, decoder, err)
+	}
+	state.relocateAll(&alert)
+	return &alert, state.warnings, nil
+}
+
+// warn records a Warning for the element starting at the input offset.
+func (s *parseState) warn(elem xml.StartElement, offset int64, val, msg string) {
+	s.warnings = append(s.warnings, Warning{
+		Element: elem.Name.Local,
+		Value:   val,
+		Message: msg,
+		offset:  offset,
+	})
+}
+
+// stateOf returns the parseState of the decoder, or nil for decoders not
+// created by ParseCAPWithOptions.
+func stateOf(decoder *xml.Decoder) *parseState {
+	state, ok := parseStates.Load(decoder)
+	if !ok {
+		return nil
+	}
+	return state.(*parseState)
+}
+
+// Code is implemented by each of the CAP code types, such as Status and
+// Category. Codes kept by lenient parsing are negative, and refer to the
+// Unrecognized texts of the Alert or Info holding them.
+type Code interface {
+	fmt.Stringer
+	code() int
+	validate() error
+}
+
+// CodeText returns the element text of a code of the Alert, such as its
+// Status. For codes kept by lenient parsing, this is the original unrecognized
+// text.
+func (t *Alert) CodeText(code Code) string {
+	if text, ok := keptText(t.Unrecognized, code); ok {
+		return text
+	}
+	return code.String()
+}
+
+// CodeText returns the element text of a code of the Info, such as its
+// Severity. For codes kept by lenient parsing, this is the original
+// unrecognized text.
+func (t *Info) CodeText(code Code) string {
+	if text, ok := keptText(t.Unrecognized, code); ok {
+		return text
+	}
+	return code.String()
+}
+
+// keptText returns the text of a code kept by lenient parsing.
+func keptText(texts []string, code Code) (string, bool) {
+	n := code.code()
+	if n >= 0 || -n > len(texts) {
+		return "", false
+	}
+	return texts[-n-1], true
+}
+
+// keep returns the negative code of an unrecognized text within a parse,
+// recording the text if required.
+func (s *parseState) keep(text string) int {
+	for i, kept := range s.kept {
+		if kept == text {
+			return -i - 1
+		}
+	}
+	s.kept = append(s.kept, text)
+	return -len(s.kept)
+}
+
+// relocate moves a code kept within the parse into the texts of the Alert or
+// Info holding it, and returns its new code.
+func (s *parseState) relocate(code int, texts *[]string) int {
+	if code >= 0 || -code > len(s.kept) {
+		return code
+	}
+	text := s.kept[-code-1]
+	for i, kept := range *texts {
+		if kept == text {
+			return -i - 1
+		}
+	}
+	*texts = append(*texts, text)
+	return -len(*texts)
+}
+
+// relocateAll moves every code kept within the parse into the Alert and Info
+// blocks holding them.
+func (s *parseState) relocateAll(alert *Alert) {
+	if len(s.kept) == 0 {
+		return
+	}
+	alert.Status = Status(s.relocate(int(alert.Status), &alert.Unrecognized))
+	alert.MsgType = MsgType(s.relocate(int(alert.MsgType), &alert.Unrecognized))
+	alert.Scope = Scope(s.relocate(int(alert.Scope), &alert.Unrecognized))
+	for i := range alert.Info {
+		info := &alert.Info[i]
+		for j := range info.Category {
+			info.Category[j] = Category(s.relocate(int(info.Category[j]), &info.Unrecognized))
+		}
+		for j := range info.ResponseType {
+			info.ResponseType[j] = ResponseType(s.relocate(int(info.ResponseType[j]), &info.Unrecognized))
+		}
+		info.Urgency = Urgency(s.relocate(int(info.Urgency), &info.Unrecognized))
+		info.Severity = Severity(s.relocate(int(info.Severity), &info.Unrecognized))
+		info.Certainty = Certainty(s.relocate(int(info.Certainty), &info.Unrecognized))
+	}
+}
+
+// lenientCode resolves a code value that is not defined by CAP 1.2, whose
+// element starts at the input offset. If the decoder is lenient, the value is
+// matched by fold, or otherwise kept, and a Warning is recorded. If the
+// decoder is strict, false is returned.
+func lenientCode(decoder *xml.Decoder, elem xml.StartElement, offset int64, val string, fold func(string) (int, bool)) (int, bool) {
+	state := stateOf(decoder)
+	if state == nil || !state.opts.Lenient {
+		return 0, false
+	}
+	if code, ok := fold(val); ok {
+		state.warn(elem, offset, val, "matched code without regard to case")
+		return code, true
+	}
+	state.warn(elem, offset, val, "kept unrecognized code")
+	return state.keep(val), true
+}
+
+// encodeStates maps each *xml.Encoder marshaling an Alert or Info with kept
+// codes to the Unrecognized texts of that Alert or Info, allowing MarshalXML
+// methods of codes to write their original text.
+var encodeStates sync.Map
+
+// hasKept reports whether the Alert has a code kept by lenient parsing, not
+// counting those of its Info blocks.
+func (t *Alert) hasKept() bool {
+	return t.Status < 0 || t.MsgType < 0 || t.Scope < 0
+}
+
+// hasKept reports whether the Info has a code kept by lenient parsing.
+func (t *Info) hasKept() bool {
+	for _, category := range t.Category {
+		if category < 0 {
+			return true
+		}
+	}
+	for _, responseType := range t.ResponseType {
+		if responseType < 0 {
+			return true
+		}
+	}
+	return t.Urgency < 0 || t.Severity < 0 || t.Certainty < 0
+}
+
+// encodeKept calls encode with the texts registered for the codes marshaled by
+// the encoder, restoring the previous texts afterwards.
+func encodeKept(encoder *xml.Encoder, texts []string, encode func() error) error {
+	prev, nested := encodeStates.Load(encoder)
+	encodeStates.Store(encoder, texts)
+	defer func() {
+		if nested {
+			encodeStates.Store(encoder, prev)
+		} else {
+			encodeStates.Delete(encoder)
+		}
+	}()
+	return encode()
+}
+
+// MarshalXML encodes the Alert, writing codes kept by lenient parsing as their
+// original text.
+func (t Alert) MarshalXML(encoder *xml.Encoder, elem xml.StartElement) error {
+	type alert Alert
+	if !t.hasKept() {
+		return encoder.Encode(alert(t))
+	}
+	return encodeKept(encoder, t.Unrecognized, func() error {
+		return encoder.Encode(alert(t))
+	})
+}
+
+// MarshalXML encodes the Info, writing codes kept by lenient parsing as their
+// original text.
+func (t Info) MarshalXML(encoder *xml.Encoder, elem xml.StartElement) error {
+	type info Info
+	if !t.hasKept() {
+		return encoder.Encode(info(t))
+	}
+	return encodeKept(encoder, t.Unrecognized, func() error {
+		return encoder.Encode(info(t))
+	})
+}
+
+// marshalCode encodes the text of a code. The error of validating the code is
+// returned, unless the code was kept by lenient parsing and its text is
+// registered with the encoder.
+func marshalCode(encoder *xml.Encoder, elem xml.StartElement, code Code) error {
+	if err := code.validate(); err != nil {
+		texts, _ := encodeStates.Load(encoder)
+		list, _ := texts.([]string)
+		text, ok := keptText(list, code)
+		if !ok {
+			return err
+		}
+		return encoder.EncodeElement(text, elem)
+	}
+	return encoder.EncodeElement(code.String(), elem)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/thetannerryan/cap"
+)
+
+// deviantAlert contains an unrecognized category and a lowercase severity.
+var deviantAlert = []byte(`<alert xmlns="urn:oasis:names:tc:emergency:cap:1.2">
+	<identifier>deviant</identifier>
+	<status>Actual</status>
+	<info>
+		<category>Weather</category>
+		<category>Met</category>
+		<severity>severe</severity>
+		<urgency> Immediate </urgency>
+	</info>
+</alert>`)
+
+// TestLenient tests that lenient parsing keeps unrecognized codes, matches
+// codes without regard to case and reports each as a Warning.
+func TestLenient(t *testing.T) {
+	_, err := cap.ParseCAP(deviantAlert)
+	test(t, "Strict error", "Error: illegal value Weather for Category code at alert/info[1]/category[1] (line 5, column 3)", fmt.Sprint(err))
+	_, _, err = cap.ParseCAPWithOptions(deviantAlert, cap.ParseOptions{})
+	test(t, "Strict options error", "Error: illegal value Weather for Category code at alert/info[1]/category[1] (line 5, column 3)", fmt.Sprint(err))
+
+	alert, warnings, err := cap.ParseCAPWithOptions(deviantAlert, cap.ParseOptions{Lenient: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := alert.Info[0]
+	test(t, "Lenient category text", "Weather", info.CodeText(info.Category[0]))
+	test(t, "Lenient category", "Met", info.Category[1].String())
+	test(t, "Lenient severity", "Severe", info.Severity.String())
+	test(t, "Lenient urgency", "Immediate", info.Urgency.String())
+	test(t, "Lenient status", "Actual", alert.CodeText(alert.Status))
+
+	var messages []string
+	for _, warning := range warnings {
+		messages = append(messages, warning.String())
+	}
+	test(t, "Lenient warnings", `alert/info[1]/category[1]: kept unrecognized code "Weather"; alert/info[1]/severity: matched code without regard to case "severe"; alert/info[1]/urgency: matched code without regard to case " Immediate "`, strings.Join(messages, "; "))
+
+	// unrecognized codes round trip, while recognized codes are normalized
+	out, err := xml.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Lenient round trip category", "true", fmt.Sprint(strings.Contains(string(out), "<category>Weather</category><category>Met</category>")))
+	test(t, "Lenient round trip severity", "true", fmt.Sprint(strings.Contains(string(out), "<severity>Severe</severity>")))
+}
+
+// TestLenientIsolation tests that codes kept by lenient parsing belong to the
+// Alert that was parsed, and never to other documents.
+func TestLenientIsolation(t *testing.T) {
+	// many distinct unrecognized values never exhaust lenient parsing
+	var alert *cap.Alert
+	for i := 0; i < 2000; i++ {
+		doc := strings.Replace(string(deviantAlert), "Weather", fmt.Sprintf("Weather%d", i), 1)
+		var err error
+		alert, _, err = cap.ParseCAPWithOptions([]byte(doc), cap.ParseOptions{Lenient: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	info := &alert.Info[0]
+	test(t, "Isolation text", "Weather1999", info.CodeText(info.Category[0]))
+	test(t, "Isolation unrecognized", "[Weather1999]", fmt.Sprint(info.Unrecognized))
+
+	// a kept code is not valid without the text of its Info
+	other := cap.Info{Category: []cap.Category{info.Category[0]}}
+	test(t, "Isolation other text", "Category(-1)", other.CodeText(other.Category[0]))
+	_, err := xml.Marshal(other)
+	test(t, "Isolation other marshal", "Error: illegal value Category(-1) for Category code", fmt.Sprint(err))
+
+	out, err := xml.Marshal(alert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Isolation marshal", "true", fmt.Sprint(strings.Contains(string(out), "<category>Weather1999</category>")))
+	var errs cap.ValidationErrors
+	if errors.As(alert.Validate(), &errs) {
+		for _, e := range errs {
+			if strings.Contains(e.Path, "category") {
+				t.Errorf("Kept code reported as %s", e)
+			}
+		}
+	}
+}
@@ -15,28 +15,83 @@ type List struct {
 	val []string
 }
 
+// NewList returns a List of the values.
+func NewList(vals ...string) List {
+	return List{val: vals}
+}
+
 // listDelimeter is for joining/splitting values
 var listDelimeter = " "
 
 // String returns the a joined string representation of the values, delimited
-// with the listDelimeter.
+// with the listDelimeter. Values containing whitespace are enclosed in double
+// quotes.
 func (t *List) String() string {
-	return strings.Join(t.val, listDelimeter)
+	vals := make([]string, len(t.val))
+	for i, val := range t.val {
+		if val == "" || strings.ContainsAny(val, " \t\r\n") {
+			val = `"` + val + `"`
+		}
+		vals[i] = val
+	}
+	return strings.Join(vals, listDelimeter)
 }
 
 // parseString will initialize a List struct given a string of values, separated
-// by the listDelimeter
+// by whitespace. Values containing whitespace are enclosed in double quotes.
 func parseString(t *List, val string) error {
-	vals := strings.Split(val, listDelimeter)
+	var vals []string
+	for i := 0; i < len(val); {
+		switch {
+		case isListSpace(val[i]):
+			i++
+		case val[i] == '"':
+			end := strings.IndexByte(val[i+1:], '"')
+			if end < 0 {
+				// unterminated quote, take the remainder as the value
+				end = len(val) - i - 1
+			}
+			vals = append(vals, val[i+1:i+1+end])
+			i += end + 2
+		default:
+			end := i
+			for end < len(val) && !isListSpace(val[end]) {
+				end++
+			}
+			vals = append(vals, val[i:end])
+			i = end
+		}
+	}
 	t.val = vals
 	return nil
 }
 
+// isListSpace returns true for the whitespace characters separating the values
+// of a List.
+func isListSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
 // Values returns a standard string slice .
 func (t *List) Values() []string {
 	return t.val
 }
 
+// Len returns the number of values within the List.
+func (t *List) Len() int {
+	return len(t.val)
+}
+
+// Contains returns true if the value is within the List.
+func (t *List) Contains(val string) bool {
+	for _, v := range t.val {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
 // UnmarshalXML will be used during the XML unmarshaling for conversion to List.
 func (t *List) UnmarshalXML(decoder *xml.Decoder, elem xml.StartElement) error {
 	var val string
@@ -46,8 +101,12 @@ func (t *List) UnmarshalXML(decoder *xml.Decoder, elem xml.StartElement) error {
 	return parseString(t, val)
 }
 
-// MarshalXML converts the List back to a string when marshaling XML.
+// MarshalXML converts the List back to a string when marshaling XML. An empty
+// List is unset, and no element is written.
 func (t List) MarshalXML(encoder *xml.Encoder, elem xml.StartElement) error {
+	if len(t.val) == 0 {
+		return nil
+	}
 	return encoder.EncodeElement(t.String(), elem)
 }
 
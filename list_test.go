@@ -0,0 +1,59 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/thetannerryan/cap"
+)
+
+// TestList tests the parsing and quoting of group listings.
+func TestList(t *testing.T) {
+	cases := []struct {
+		text   string
+		values string
+		string string
+	}{
+		{`"Fire Dept Zone 3" ops@x`, "Fire Dept Zone 3|ops@x", `"Fire Dept Zone 3" ops@x`},
+		{"a  b\n\tc ", "a|b|c", "a b c"},
+		{"", "", ""},
+		{`"" a`, "|a", `"" a`},
+		{`a "b c`, "a|b c", `a "b c"`},
+	}
+	for _, c := range cases {
+		var list cap.List
+		if err := json.Unmarshal([]byte(fmt.Sprintf("%q", c.text)), &list); err != nil {
+			t.Fatal(err)
+		}
+		test(t, "List values "+c.text, c.values, strings.Join(list.Values(), "|"))
+		test(t, "List string "+c.text, c.string, list.String())
+	}
+}
+
+// TestAddresses tests that private addresses are parsed as a List.
+func TestAddresses(t *testing.T) {
+	var alert cap.Alert
+	data := `<alert xmlns="urn:oasis:names:tc:emergency:cap:1.2"><scope>Private</scope>` +
+		`<addresses>"Fire Dept Zone 3" ops@example.org</addresses></alert>`
+	if err := xml.Unmarshal([]byte(data), &alert); err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Addresses length", "2", fmt.Sprint(alert.Addresses.Len()))
+	test(t, "Addresses contains", "true", fmt.Sprint(alert.Addresses.Contains("Fire Dept Zone 3")))
+
+	out, err := xml.Marshal(alert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Addresses round trip", "true", fmt.Sprint(strings.Contains(string(out), `<addresses>&#34;Fire Dept Zone 3&#34; ops@example.org</addresses>`)))
+
+	built := build(t, cap.NewAlert("cap@example.org").Scope(cap.ScopePrivate).Addresses("Fire Dept Zone 3", "ops@example.org"))
+	test(t, "Built addresses", `"Fire Dept Zone 3" ops@example.org`, built.Addresses.String())
+}
@@ -7,9 +7,11 @@ package cap
 import (
 	"encoding/json"
 	"encoding/xml"
-	"errors"
+	"strings"
 )
 
+//go:generate stringer -type=MsgType -trimprefix=MsgType
+
 // MsgType is a code denoting the appropriate handling of the alert message
 type MsgType int
 
@@ -46,36 +48,60 @@ var (
 func stringToMsgTypeCode(t *MsgType, val string) error {
 	enum, ok := MsgTypeMapping[val]
 	if !ok {
-		return errors.New("Error: illegal value " + val + " for MsgType code")
+		return illegalValue(val, "MsgType")
 	}
 	*t = enum
 	return nil
 }
 
-// String converts the MsgType code back to a string.
-func (t MsgType) String() string {
-	for key, val := range MsgTypeMapping {
-		if val == t {
-			return key
+// foldMsgTypeCode will perform the mapping of string to a MsgType code without
+// regard to case or surrounding whitespace.
+func foldMsgTypeCode(val string) (int, bool) {
+	val = strings.TrimSpace(val)
+	for key, enum := range MsgTypeMapping {
+		if strings.EqualFold(key, val) {
+			return int(enum), true
 		}
 	}
-	// logically never reached
-	return ""
+	return 0, false
+}
+
+// code returns the number of the MsgType code.
+func (t MsgType) code() int {
+	return int(t)
+}
+
+// validate returns an error if the MsgType is not one of the defined codes.
+func (t MsgType) validate() error {
+	if t < 0 || int(t) >= len(_MsgType_index)-1 {
+		return illegalValue(t.String(), "MsgType")
+	}
+	return nil
 }
 
 // UnmarshalXML will be used during the XML unmarshaling for conversion to
 // MsgType code.
 func (t *MsgType) UnmarshalXML(decoder *xml.Decoder, elem xml.StartElement) error {
+	offset := decoder.InputOffset()
 	var val string
 	if err := decoder.DecodeElement(&val, &elem); err != nil {
 		return err
 	}
-	return stringToMsgTypeCode(t, val)
+	err := stringToMsgTypeCode(t, val)
+	if err == nil {
+		return nil
+	}
+	code, ok := lenientCode(decoder, elem, offset, val, foldMsgTypeCode)
+	if !ok {
+		return atOffset(err, offset)
+	}
+	*t = MsgType(code)
+	return nil
 }
 
 // MarshalXML converts the MsgType code back to a string when marshaling XML.
 func (t MsgType) MarshalXML(encoder *xml.Encoder, elem xml.StartElement) error {
-	return encoder.EncodeElement(t.String(), elem)
+	return marshalCode(encoder, elem, t)
 }
 
 // UnmarshalJSON will be used during the JSON unmarshaling for conversion to
@@ -90,5 +116,8 @@ func (t *MsgType) UnmarshalJSON(buff []byte) error {
 
 // MarshalJSON converts the MsgType code back to a string when marshaling JSON.
 func (t MsgType) MarshalJSON() ([]byte, error) {
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
 	return json.Marshal(t.String())
 }
@@ -0,0 +1,25 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap
+
+// Priority returns a composite importance score for the Info, between 0 and
+// 124. Severity is weighed first, then Urgency, then Certainty, so that an Info
+// of greater Severity always outranks one of lesser Severity.
+func (t *Info) Priority() int {
+	return t.Severity.Rank()*25 + t.Urgency.Rank()*5 + t.Certainty.Rank()
+}
+
+// MostSevereInfo returns the Info of the Alert with the highest Priority. Where
+// several Info share the highest Priority, the first is returned. If the Alert
+// has no Info, nil is returned.
+func (t *Alert) MostSevereInfo() *Info {
+	var most *Info
+	for i := range t.Info {
+		if most == nil || t.Info[i].Priority() > most.Priority() {
+			most = &t.Info[i]
+		}
+	}
+	return most
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/thetannerryan/cap"
+)
+
+// TestRankCompare tests that Unknown ranks below every defined code.
+func TestRankCompare(t *testing.T) {
+	test(t, "Severity extreme rank", "4", strconv.Itoa(cap.SeverityExtreme.Rank()))
+	test(t, "Severity unknown rank", "0", strconv.Itoa(cap.SeverityUnknown.Rank()))
+	test(t, "Severity compare", "1", strconv.Itoa(cap.SeverityMinor.Compare(cap.SeverityUnknown)))
+	test(t, "Urgency compare", "-1", strconv.Itoa(cap.UrgencyPast.Compare(cap.UrgencyFuture)))
+	test(t, "Certainty compare", "0", strconv.Itoa(cap.CertaintyLikely.Compare(cap.CertaintyLikely)))
+	test(t, "Certainty invalid rank", "0", strconv.Itoa(cap.Certainty(42).Rank()))
+}
+
+// TestMostSevereInfo tests that Severity outweighs Urgency and Certainty.
+func TestMostSevereInfo(t *testing.T) {
+	alert := cap.Alert{Info: []cap.Info{
+		{Event: "a", Severity: cap.SeverityModerate, Urgency: cap.UrgencyImmediate, Certainty: cap.CertaintyObserved},
+		{Event: "b", Severity: cap.SeveritySevere, Urgency: cap.UrgencyUnknown, Certainty: cap.CertaintyUnknown},
+		{Event: "c", Severity: cap.SeveritySevere, Urgency: cap.UrgencyFuture, Certainty: cap.CertaintyPossible},
+	}}
+	test(t, "Priority", "74", strconv.Itoa(alert.Info[0].Priority()))
+	test(t, "Most severe info", "c", alert.MostSevereInfo().Event)
+	test(t, "Most severe info empty", "true", strconv.FormatBool((&cap.Alert{}).MostSevereInfo() == nil))
+}
@@ -36,24 +36,26 @@ import "encoding/xml"
 // for message acknowledgements, cancellations or other system functions, but
 // most Alert struct will include at least one Info struct.
 type Alert struct {
-	XMLName xml.Name `xml:"urn:oasis:names:tc:emergency:cap:1.2 alert" json:"alert"` // Reference CAP URN (REQUIRED)
-
-	Identifier  string   `xml:"identifier" json:"identifier"`   // Identifier of the alert message (REQUIRED)
-	Sender      string   `xml:"sender" json:"sender"`           // Identifier of the sender of the alert message (REQUIRED)
-	Sent        DateTime `xml:"sent" json:"sent"`               // Time and date of the origination of the alert message (REQUIRED)
-	Status      Status   `xml:"status" json:"status"`           // Code denoting the appropriate handling of the alert message (REQUIRED)
-	MsgType     MsgType  `xml:"msgType" json:"msgType"`         // Code denoting the nature of the alert message (REQUIRED)
-	Source      string   `xml:"source" json:"source"`           // Text identifying the source of the alert message
-	Scope       Scope    `xml:"scope" json:"scope"`             // Code denoting the intended distribution of the alert message (REQUIRED)
-	Restriction string   `xml:"restriction" json:"restriction"` // Text describing the rule for limiting the distribution of the restricted alert message (CONDITIONAL)
-	Addresses   string   `xml:"addresses" json:"addresses"`     // Group listing of intended recipients of the alert message (CONDITIONAL)
-	Code        []string `xml:"code" json:"code"`               // Code denoting special handling of the alert message
-	Note        string   `xml:"note" json:"note"`               // Text describing the purpose or significance of the alert message
-	References  List     `xml:"references" json:"references"`   // Group listing identifying earlier message(s) reference by the alert message
-	Incidents   string   `xml:"incidents" json:"incidents"`     // Group listing naming the referent incident(s) of the alert message
+	XMLName xml.Name `xml:"urn:oasis:names:tc:emergency:cap:1.2 alert" json:"-"` // Reference CAP URN (REQUIRED)
+
+	Identifier  string   `xml:"identifier" json:"identifier"`             // Identifier of the alert message (REQUIRED)
+	Sender      string   `xml:"sender" json:"sender"`                     // Identifier of the sender of the alert message (REQUIRED)
+	Sent        DateTime `xml:"sent" json:"sent"`                         // Time and date of the origination of the alert message (REQUIRED)
+	Status      Status   `xml:"status" json:"status"`                     // Code denoting the appropriate handling of the alert message (REQUIRED)
+	MsgType     MsgType  `xml:"msgType" json:"msgType"`                   // Code denoting the nature of the alert message (REQUIRED)
+	Source      string   `xml:"source,omitempty" json:"source"`           // Text identifying the source of the alert message
+	Scope       Scope    `xml:"scope" json:"scope"`                       // Code denoting the intended distribution of the alert message (REQUIRED)
+	Restriction string   `xml:"restriction,omitempty" json:"restriction"` // Text describing the rule for limiting the distribution of the restricted alert message (CONDITIONAL)
+	Addresses   List     `xml:"addresses" json:"addresses"`               // Group listing of intended recipients of the alert message (CONDITIONAL)
+	Code        []string `xml:"code" json:"code"`                         // Code denoting special handling of the alert message
+	Note        string   `xml:"note,omitempty" json:"note"`               // Text describing the purpose or significance of the alert message
+	References  List     `xml:"references" json:"references"`             // Group listing identifying earlier message(s) reference by the alert message
+	Incidents   List     `xml:"incidents" json:"incidents"`               // Group listing naming the referent incident(s) of the alert message
 
 	Info      []Info      `xml:"info" json:"info"`           // Container for all component parts of the info sub-element of the alert message
 	Signature []Signature `xml:"Signature" json:"signature"` // Standard XML Digital Signature, not originally defined in CAP, used in CAP-CP and NAADS
+
+	Unrecognized []string `xml:"-" json:"-"` // Text of the status, msgType and scope codes kept by lenient parsing, referenced by negative codes
 }
 
 // Info struct describes an anticipated or actual event in terms of its urgency
@@ -67,44 +69,46 @@ type Alert struct {
 // probability or intensity “bands”) or to provide the information in multiple
 // languages.
 type Info struct {
-	XMLName xml.Name `xml:"info"` // Info CAP
-
-	Language     string         `xml:"language" json:"language"`         // Code denoting the language of the info sub-element of the alert message
-	Category     []Category     `xml:"category" json:"category"`         // Code denoting the category of the subject event of the alert message (REQUIRED)
-	Event        string         `xml:"event" json:"event"`               // Text denoting the type of the subject event of the alert message (REQUIRED)
-	ResponseType []ResponseType `xml:"responseType" json:"responseType"` // Code denoting the type of action recommended for the target audience
-	Urgency      Urgency        `xml:"urgency" json:"urgency"`           // Code denoting the urgency of the subject event of the alert message (REQUIRED)
-	Severity     Severity       `xml:"severity" json:"severity"`         // Code denoting the severity of the subject event of the alert message (REQUIRED)
-	Certainty    Certainty      `xml:"certainty" json:"certainty"`       // Code denoting the certainty of the subject event of the alert message (REQUIRED)
-	Audience     string         `xml:"audience" json:"audience"`         // Text describing the intended audience of the alert message
-	EventCode    []KeyValue     `xml:"eventCode" json:"eventCode"`       // System-specific code identifying the event type of the alert message
-	Effective    DateTime       `xml:"effective" json:"effective"`       // Effective time of the information of the alert message
-	Onset        DateTime       `xml:"onset" json:"onset"`               // Expected time of the beginning of the subject event of the alert message
-	Expires      DateTime       `xml:"expires" json:"expires"`           // Expiry time of the information of the alert message
-	SenderName   string         `xml:"senderName" json:"senderName"`     // Text naming the originator of the alert message
-	Headline     string         `xml:"headline" json:"headline"`         // Text headline of the alert message
-	Description  string         `xml:"description" json:"description"`   // Text describing the subject event of the alert message
-	Instruction  string         `xml:"instruction" json:"instruction"`   // Text describing the recommended action to be taken by recipients of the alert message
-	Web          string         `xml:"web" json:"web"`                   // Identifier of the hyperlink associating additional information with the alert message
-	Contact      string         `xml:"contact" json:"contact"`           // Text describing the contact for follow-up and confirmation of the alert message
-	Parameter    []KeyValue     `xml:"parameter" json:"parameter"`       // System-specific additional parameter associated with the alert message
+	XMLName xml.Name `xml:"info" json:"-"` // Info CAP
+
+	Language     string         `xml:"language,omitempty" json:"language"`       // Code denoting the language of the info sub-element of the alert message
+	Category     []Category     `xml:"category" json:"category"`                 // Code denoting the category of the subject event of the alert message (REQUIRED)
+	Event        string         `xml:"event" json:"event"`                       // Text denoting the type of the subject event of the alert message (REQUIRED)
+	ResponseType []ResponseType `xml:"responseType" json:"responseType"`         // Code denoting the type of action recommended for the target audience
+	Urgency      Urgency        `xml:"urgency" json:"urgency"`                   // Code denoting the urgency of the subject event of the alert message (REQUIRED)
+	Severity     Severity       `xml:"severity" json:"severity"`                 // Code denoting the severity of the subject event of the alert message (REQUIRED)
+	Certainty    Certainty      `xml:"certainty" json:"certainty"`               // Code denoting the certainty of the subject event of the alert message (REQUIRED)
+	Audience     string         `xml:"audience,omitempty" json:"audience"`       // Text describing the intended audience of the alert message
+	EventCode    []KeyValue     `xml:"eventCode" json:"eventCode"`               // System-specific code identifying the event type of the alert message
+	Effective    DateTime       `xml:"effective" json:"effective"`               // Effective time of the information of the alert message
+	Onset        DateTime       `xml:"onset" json:"onset"`                       // Expected time of the beginning of the subject event of the alert message
+	Expires      DateTime       `xml:"expires" json:"expires"`                   // Expiry time of the information of the alert message
+	SenderName   string         `xml:"senderName,omitempty" json:"senderName"`   // Text naming the originator of the alert message
+	Headline     string         `xml:"headline,omitempty" json:"headline"`       // Text headline of the alert message
+	Description  string         `xml:"description,omitempty" json:"description"` // Text describing the subject event of the alert message
+	Instruction  string         `xml:"instruction,omitempty" json:"instruction"` // Text describing the recommended action to be taken by recipients of the alert message
+	Web          string         `xml:"web,omitempty" json:"web"`                 // Identifier of the hyperlink associating additional information with the alert message
+	Contact      string         `xml:"contact,omitempty" json:"contact"`         // Text describing the contact for follow-up and confirmation of the alert message
+	Parameter    []KeyValue     `xml:"parameter" json:"parameter"`               // System-specific additional parameter associated with the alert message
 
 	Resource []Resource `xml:"resource" json:"resource"` // Container for all component parts of the resource sub-element of the info sub-element of the alert element
 	Area     []Area     `xml:"area" json:"area"`         // Container for all component parts of the area sub-element of the info sub-element of the alert message
+
+	Unrecognized []string `xml:"-" json:"-"` // Text of the codes kept by lenient parsing, referenced by negative codes
 }
 
 // Resource struct provides an optional reference to additional information
 // related to the Info struct within which it appears in the form of a digital
 // asset such as an image or audio file.
 type Resource struct {
-	XMLName xml.Name `xml:"resource" json:"resource"` // Resouce CAP
+	XMLName xml.Name `xml:"resource" json:"-"` // Resouce CAP
 
-	ResourceDesc string `xml:"resourceDesc" json:"resourceDesc"` // Text describing the type and content of the resource file (REQUIRED)
-	MimeType     string `xml:"mimeType" json:"mimeType"`         // Identifier of the MIME content type and sub-type describing the resource file (REQUIRED)
-	Size         int    `xml:"size" json:"size"`                 // Integer indicating the size of the resource file
-	URI          string `xml:"uri" json:"uri"`                   // Identifier of the hyperlink for the resource file
-	DerefURI     string `xml:"derefUri" json:"derefUri"`         // Base-64 encoded data content of the resource file (CONDITIONAL)
-	Digest       string `xml:"digest" json:"digest"`             // Code representing the digital digest ("hash") computed from the resource file
+	ResourceDesc string `xml:"resourceDesc" json:"resourceDesc"`   // Text describing the type and content of the resource file (REQUIRED)
+	MimeType     string `xml:"mimeType" json:"mimeType"`           // Identifier of the MIME content type and sub-type describing the resource file (REQUIRED)
+	Size         int    `xml:"size,omitempty" json:"size"`         // Integer indicating the size of the resource file
+	URI          string `xml:"uri,omitempty" json:"uri"`           // Identifier of the hyperlink for the resource file
+	DerefURI     string `xml:"derefUri,omitempty" json:"derefUri"` // Base-64 encoded data content of the resource file (CONDITIONAL)
+	Digest       string `xml:"digest,omitempty" json:"digest"`     // Code representing the digital digest ("hash") computed from the resource file
 
 }
 
@@ -115,14 +119,14 @@ type Resource struct {
 // latitude / longitude / altitude terms in accordance with a specified
 // geospatial datum.
 type Area struct {
-	XMLName xml.Name `xml:"area" json:"area"` // Area CAP
-
-	AreaDesc string     `xml:"areaDesc" json:"areaDesc"` // Text describing the affected area of the alert message (REQUIRED)
-	Polygon  List       `xml:"polygon" json:"polygon"`   // Paired values of points defining a polygon that delineates the affected area of the alert message
-	Circle   []string   `xml:"circle" json:"circle"`     // Paired values of a point and radius delineating the affected area of the alert message
-	Geocode  []KeyValue `xml:"geocode" json:"geocode"`   // Geographic code delineating the affected area of the alert message
-	Altitude float32    `xml:"altitude" json:"altitude"` // Specific or minimum altitude of the affected area of the alert message
-	Ceiling  float32    `xml:"ceiling" json:"ceiling"`   // Maximum altitude of the affected area of the alert message (CONDITIONAL)
+	XMLName xml.Name `xml:"area" json:"-"` // Area CAP
+
+	AreaDesc string     `xml:"areaDesc" json:"areaDesc"`           // Text describing the affected area of the alert message (REQUIRED)
+	Polygon  List       `xml:"polygon" json:"polygon"`             // Paired values of points defining a polygon that delineates the affected area of the alert message
+	Circle   []string   `xml:"circle" json:"circle"`               // Paired values of a point and radius delineating the affected area of the alert message
+	Geocode  []KeyValue `xml:"geocode" json:"geocode"`             // Geographic code delineating the affected area of the alert message
+	Altitude float32    `xml:"altitude,omitempty" json:"altitude"` // Specific or minimum altitude of the affected area of the alert message
+	Ceiling  float32    `xml:"ceiling,omitempty" json:"ceiling"`   // Maximum altitude of the affected area of the alert message (CONDITIONAL)
 }
 
 // KeyValue is a generic element for representing key-value pairs
@@ -135,7 +139,7 @@ type KeyValue struct {
 // original CAP protocol, but is implemented in CAP-CP and is enforced in
 // Pelmorex's National Alert Aggregation & Dissemination System (NAADS).
 type Signature struct {
-	XMLName xml.Name `xml:"http://www.w3.org/2000/09/xmldsig# Signature" json:"signature"`
+	XMLName xml.Name `xml:"http://www.w3.org/2000/09/xmldsig# Signature" json:"-"`
 
 	ID                  string              `xml:"Id,attr" json:"id"`
 	SignedInfo          SignedInfo          `xml:"SignedInfo" json:"signedInfo"`
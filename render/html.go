@@ -0,0 +1,178 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"bytes"
+	"encoding/base64"
+	"hash/fnv"
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/thetannerryan/cap"
+)
+
+// severityColors are the banner colors of each Severity, keyed on its CAP
+// text. Each has a contrast ratio of at least 4.5:1 against white text.
+var severityColors = map[string]string{
+	"Extreme":  "#9b1c1c",
+	"Severe":   "#b8400b",
+	"Moderate": "#8a6100",
+	"Minor":    "#1d4e89",
+	"Unknown":  "#4a4a4a",
+}
+
+// imageTypes matches the MIME types of resources that may be embedded from
+// their derefUri. SVG images are excluded, as they may carry script.
+var imageTypes = regexp.MustCompile(`^image/(png|jpeg|gif|webp)$`)
+
+// section is the value the HTML template is executed against for each Info.
+type section struct {
+	Data
+	ID     string
+	Banner template.CSS
+	Map    template.HTML
+	Sent   cap.DateTime
+}
+
+// resource is a Resource prepared for the HTML template.
+type resource struct {
+	Desc  string
+	URI   string
+	Image template.URL
+}
+
+// htmlTemplate renders an Info as a section of the HTML fragment.
+var htmlTemplate = template.Must(template.New("html").Funcs(htmlFuncs(Options{}, &cap.Info{}, "en")).Parse(
+	`<section class="cap-info"{{with .Info.Language}} lang="{{.}}"{{end}} aria-labelledby="{{.ID}}">
+<header class="cap-banner" style="{{.Banner}}">
+<h2 id="{{.ID}}">{{with trim .Info.Headline}}{{.}}{{else}}{{with trim .Info.Event}}{{.}}{{else}}{{label "unknown"}}{{end}}{{end}}</h2>
+<p>{{label "severity"}}: <strong>{{code .Info.Severity}}</strong> · {{label "urgency"}}: {{code .Info.Urgency}} · {{label "certainty"}}: {{code .Info.Certainty}}</p>
+</header>
+<dl>
+<dt>{{label "event"}}</dt><dd>{{with trim .Info.Event}}{{.}}{{else}}{{label "unknown"}}{{end}}</dd>
+{{- with datetime .Info.Effective}}
+<dt>{{label "effective"}}</dt><dd>{{.}}</dd>
+{{- end}}
+<dt>{{label "expires"}}</dt><dd>{{with datetime .Info.Expires}}{{.}}{{else}}{{label "untilNotice"}}{{end}}</dd>
+{{- with areas .Info}}
+<dt>{{label "areas"}}</dt><dd>{{join . ", "}}</dd>
+{{- end}}
+</dl>
+{{- with .Map}}
+<figure class="cap-map">{{.}}</figure>
+{{- end}}
+{{- with paragraphs .Info.Description}}
+<h3>{{label "description"}}</h3>
+{{- range .}}
+<p>{{.}}</p>
+{{- end}}
+{{- end}}
+{{- with paragraphs .Info.Instruction}}
+<h3>{{label "instruction"}}</h3>
+{{- range .}}
+<p>{{.}}</p>
+{{- end}}
+{{- end}}
+{{- with resources .Info}}
+<h3>{{label "resources"}}</h3>
+<ul>
+{{- range .}}
+<li>{{if .Image}}<figure><img src="{{.Image}}" alt="{{.Desc}}"><figcaption>{{.Desc}}</figcaption></figure>{{else if .URI}}<a href="{{.URI}}">{{.Desc}}</a>{{else}}{{.Desc}}{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- with trim .Info.Contact}}
+<p>{{label "contact"}}: {{.}}</p>
+{{- end}}
+{{- with trim .Info.Web}}
+<p><a href="{{.}}">{{label "web"}}</a></p>
+{{- end}}
+{{- with datetime .Sent}}
+<footer><p>{{label "sent"}}: {{.}}</p></footer>
+{{- end}}
+</section>
+`))
+
+// htmlFuncs returns the helper functions of the HTML template.
+func htmlFuncs(opts Options, info *cap.Info, language string) template.FuncMap {
+	funcMap := template.FuncMap(funcs(opts, info, language))
+	funcMap["paragraphs"] = paragraphs
+	funcMap["resources"] = resources
+	return funcMap
+}
+
+// paragraphBreak matches the blank lines separating paragraphs.
+var paragraphBreak = regexp.MustCompile(`\n[ \t\r]*\n`)
+
+// paragraphs splits text into its paragraphs, separated by blank lines.
+func paragraphs(text string) []string {
+	var paras []string
+	for _, para := range paragraphBreak.Split(text, -1) {
+		if para = strings.TrimSpace(para); para != "" {
+			paras = append(paras, para)
+		}
+	}
+	return paras
+}
+
+// resources prepares the Resources of the Info. Images are embedded from their
+// derefUri if it holds valid base64 data.
+func resources(info *cap.Info) []resource {
+	var vals []resource
+	for _, res := range info.Resource {
+		val := resource{Desc: strings.TrimSpace(res.ResourceDesc), URI: strings.TrimSpace(res.URI)}
+		mimeType := strings.ToLower(strings.TrimSpace(res.MimeType))
+		if data := strings.Join(strings.Fields(res.DerefURI), ""); data != "" && imageTypes.MatchString(mimeType) {
+			if _, err := base64.StdEncoding.DecodeString(data); err == nil {
+				// the MIME type and data are both checked, so the URL is safe
+				val.Image = template.URL("data:" + mimeType + ";base64," + data)
+			}
+		}
+		vals = append(vals, val)
+	}
+	return vals
+}
+
+// HTML renders the Alert as a self-contained HTML fragment, with a section for
+// each Info in its own language. Each section has a banner colored by its
+// severity, and an inline SVG map of its polygons and circles. All text from
+// the Alert is escaped, and links with unsafe schemes are removed.
+func HTML(alert *cap.Alert, opts Options) (string, error) {
+	hash := fnv.New32a()
+	hash.Write([]byte(alert.Sender + "," + alert.Identifier))
+	prefix := "cap-" + strconv.FormatUint(uint64(hash.Sum32()), 16)
+
+	var buff bytes.Buffer
+	buff.WriteString(`<article class="cap-alert">` + "\n")
+	for i := range alert.Info {
+		info := &alert.Info[i]
+		language := labelLanguage(opts, info)
+		color, ok := severityColors[info.Severity.String()]
+		if !ok {
+			color = severityColors["Unknown"]
+		}
+		id := prefix + "-" + strconv.Itoa(i+1)
+
+		tmpl, err := htmlTemplate.Clone()
+		if err != nil {
+			return "", err
+		}
+		err = tmpl.Funcs(htmlFuncs(opts, info, language)).Execute(&buff, section{
+			Data:   Data{Info: info, Language: language},
+			ID:     id,
+			Banner: template.CSS("background-color: " + color + "; color: #ffffff; padding: 0.5em 1em"),
+			Map:    areaMap(info, id, translate(language, "map"), color),
+			Sent:   alert.Sent,
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	buff.WriteString("</article>\n")
+	return buff.String(), nil
+}
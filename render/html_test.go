@@ -0,0 +1,64 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package render_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/thetannerryan/cap"
+	"github.com/thetannerryan/cap/render"
+)
+
+// TestHTML tests the sections, banners and maps of the HTML fragment.
+func TestHTML(t *testing.T) {
+	wind := load("PelmorexNAADS_WindWarning.xml")
+	out, err := render.HTML(wind, render.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "HTML sections", "2", fmt.Sprint(strings.Count(out, `<section class="cap-info"`)))
+	test(t, "HTML languages", "true", fmt.Sprint(strings.Contains(out, `lang="fr-CA"`) && strings.Contains(out, `lang="en-CA"`)))
+	test(t, "HTML banner", "true", fmt.Sprint(strings.Contains(out, `style="background-color: #8a6100; color: #ffffff; padding: 0.5em 1em"`)))
+	test(t, "HTML map", "2", fmt.Sprint(strings.Count(out, `<polygon vector-effect="non-scaling-stroke" points="`)))
+	test(t, "HTML map title", "true", fmt.Sprint(strings.Contains(out, `>Carte des régions touchées</title>`)))
+	test(t, "HTML web", "true", fmt.Sprint(strings.Contains(out, `<a href="http://weather.gc.ca/warnings/index_e.html?prov=sqc">More information</a>`)))
+
+	earthquake := load("Oasis_EarthquakeReport.xml")
+	out, err = render.HTML(earthquake, render.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "HTML circle", "true", fmt.Sprint(strings.Contains(out, `<circle vector-effect="non-scaling-stroke"`)))
+}
+
+// TestHTMLEscaping tests that hostile alert text cannot inject markup, script
+// or unsafe links, and that only checked images are embedded.
+func TestHTMLEscaping(t *testing.T) {
+	alert := &cap.Alert{Info: []cap.Info{{
+		Language:    `en" onmouseover="alert(1)`,
+		Headline:    `<script>alert(1)</script>`,
+		Description: "first & <b>second</b>\n\nthird",
+		Web:         "javascript:alert(1)",
+		Resource: []cap.Resource{
+			{ResourceDesc: "pixel", MimeType: "image/gif", DerefURI: "R0lGODlhAQABAAAAACw="},
+			{ResourceDesc: "vector", MimeType: "image/svg+xml", DerefURI: "PHN2Zz48L3N2Zz4="},
+			{ResourceDesc: `"><img src=x>`, MimeType: "text/html", URI: "javascript:alert(2)"},
+		},
+	}}}
+	out, err := render.HTML(alert, render.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Escaped headline", "true", fmt.Sprint(strings.Contains(out, "&lt;script&gt;alert(1)&lt;/script&gt;")))
+	test(t, "Escaped language", "true", fmt.Sprint(strings.Contains(out, `lang="en&#34; onmouseover=&#34;alert(1)"`)))
+	test(t, "Escaped paragraphs", "true", fmt.Sprint(strings.Contains(out, "<p>first &amp; &lt;b&gt;second&lt;/b&gt;</p>\n<p>third</p>")))
+	test(t, "Unsafe web", "false", fmt.Sprint(strings.Contains(out, "javascript:")))
+	test(t, "Embedded image", "true", fmt.Sprint(strings.Contains(out, `<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=" alt="pixel">`)))
+	test(t, "SVG not embedded", "false", fmt.Sprint(strings.Contains(out, "data:image/svg")))
+	test(t, "Escaped resource", "false", fmt.Sprint(strings.Contains(out, "<img src=x>")))
+	test(t, "No script", "false", fmt.Sprint(strings.Contains(out, "<script")))
+}
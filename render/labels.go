@@ -0,0 +1,144 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"strings"
+
+	"github.com/thetannerryan/cap"
+)
+
+// labels maps each supported language to its translations of the headings
+// and code values used by the renderers. Code values are keyed on their CAP
+// text.
+var labels = map[string]map[string]string{
+	"en": {
+		"event":       "Event",
+		"severity":    "Severity",
+		"urgency":     "Urgency",
+		"certainty":   "Certainty",
+		"effective":   "Effective",
+		"expires":     "Expires",
+		"untilNotice": "until further notice",
+		"areas":       "Areas",
+		"description": "Description",
+		"instruction": "Instructions",
+		"contact":     "Contact",
+		"web":         "More information",
+		"resources":   "Resources",
+		"unknown":     "Unknown",
+		"map":         "Map of the affected areas",
+		"sent":        "Sent",
+
+		"Extreme":  "Extreme",
+		"Severe":   "Severe",
+		"Moderate": "Moderate",
+		"Minor":    "Minor",
+
+		"Immediate": "Immediate",
+		"Expected":  "Expected",
+		"Future":    "Future",
+		"Past":      "Past",
+
+		"Observed": "Observed",
+		"Likely":   "Likely",
+		"Possible": "Possible",
+		"Unlikely": "Unlikely",
+
+		"Unknown": "Unknown",
+	},
+	"fr": {
+		"event":       "Événement",
+		"severity":    "Gravité",
+		"urgency":     "Urgence",
+		"certainty":   "Certitude",
+		"effective":   "En vigueur",
+		"expires":     "Expire",
+		"untilNotice": "jusqu'à nouvel ordre",
+		"areas":       "Régions",
+		"description": "Description",
+		"instruction": "Consignes",
+		"contact":     "Contact",
+		"web":         "Plus d'information",
+		"resources":   "Ressources",
+		"unknown":     "Inconnu",
+		"map":         "Carte des régions touchées",
+		"sent":        "Envoyé",
+
+		"Extreme":  "Extrême",
+		"Severe":   "Grave",
+		"Moderate": "Modérée",
+		"Minor":    "Mineure",
+
+		"Immediate": "Immédiate",
+		"Expected":  "Prévue",
+		"Future":    "Future",
+		"Past":      "Passée",
+
+		"Observed": "Observée",
+		"Likely":   "Probable",
+		"Possible": "Possible",
+		"Unlikely": "Improbable",
+
+		"Unknown": "Inconnue",
+	},
+	"es": {
+		"event":       "Evento",
+		"severity":    "Gravedad",
+		"urgency":     "Urgencia",
+		"certainty":   "Certeza",
+		"effective":   "Vigente",
+		"expires":     "Expira",
+		"untilNotice": "hasta nuevo aviso",
+		"areas":       "Áreas",
+		"description": "Descripción",
+		"instruction": "Instrucciones",
+		"contact":     "Contacto",
+		"web":         "Más información",
+		"resources":   "Recursos",
+		"unknown":     "Desconocido",
+		"map":         "Mapa de las áreas afectadas",
+		"sent":        "Enviado",
+
+		"Extreme":  "Extrema",
+		"Severe":   "Grave",
+		"Moderate": "Moderada",
+		"Minor":    "Menor",
+
+		"Immediate": "Inmediata",
+		"Expected":  "Esperada",
+		"Future":    "Futura",
+		"Past":      "Pasada",
+
+		"Observed": "Observada",
+		"Likely":   "Probable",
+		"Possible": "Posible",
+		"Unlikely": "Improbable",
+
+		"Unknown": "Desconocida",
+	},
+}
+
+// labelLanguage returns the supported language for the labels of an Info. The
+// language of the options takes precedence over that of the Info, and English
+// is used if neither is supported.
+func labelLanguage(opts Options, info *cap.Info) string {
+	for _, tag := range []string{opts.Language, info.Language} {
+		primary := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		if _, ok := labels[primary]; ok {
+			return primary
+		}
+	}
+	return "en"
+}
+
+// translate returns the label of the key in the language. Keys without a
+// label, such as code values kept by lenient parsing, are returned unchanged.
+func translate(language, key string) string {
+	if label, ok := labels[language][key]; ok {
+		return label
+	}
+	return key
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package render formats CAP alerts for people, such as in email, chat and
+notification messages.
+
+Text and Markdown render an Info with its headline, event, severity, urgency
+and certainty, the window from its effective to expiry time, its area names,
+description and instruction. Missing elements are omitted. Labels are
+translated into English, French or Spanish, following the language of the
+Info unless Options.Language is set.
+
+HTML renders a whole Alert as a self-contained HTML fragment for web pages,
+with a section per Info, a banner colored by severity, and an inline SVG map of
+its polygons and circles. Text from the Alert is escaped, so hostile alerts
+cannot inject markup or script.
+
+Custom text/template templates may use the helper functions of Funcs, and are
+executed against an Info with Execute.
+
+	tmpl := template.Must(template.New("sms").Funcs(render.Funcs(opts)).Parse(
+		`{{code .Info.Severity}}: {{.Info.Headline}} ({{join (areas .Info) ", "}})`))
+	text, err := render.Execute(tmpl, &alert.Info[0], opts)
+*/
+package render
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/thetannerryan/cap"
+)
+
+// Options configures the rendering of an Info.
+type Options struct {
+	// Location is the time zone in which times are shown. If nil, UTC is used.
+	Location *time.Location
+	// Language is the language of the labels, such as "fr". If empty, the
+	// language of the Info is used.
+	Language string
+}
+
+// Data is the value templates are executed against.
+type Data struct {
+	Info     *cap.Info // Info being rendered
+	Language string    // Language of the labels, one of "en", "fr" or "es"
+}
+
+// timeLayout is the layout of times shown by the renderers.
+const timeLayout = "2006-01-02 15:04 MST"
+
+// markdownEscaper escapes the characters that Markdown treats as formatting.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`,
+)
+
+// funcs returns the helper functions for the options, and the Info and label
+// language being rendered.
+func funcs(opts Options, info *cap.Info, language string) template.FuncMap {
+	location := opts.Location
+	if location == nil {
+		location = time.UTC
+	}
+	return template.FuncMap{
+		// label returns the translated heading of the key
+		"label": func(key string) string {
+			return translate(language, key)
+		},
+		// code returns the translated value of a code, such as a Severity
+		"code": func(code cap.Code) string {
+			return translate(language, info.CodeText(code))
+		},
+		// datetime returns the time in the location, or an empty string for
+		// unset times
+		"datetime": func(t cap.DateTime) string {
+			if t.Time().IsZero() {
+				return ""
+			}
+			return t.Time().In(location).Format(timeLayout)
+		},
+		// areas returns the descriptions of the areas of the Info
+		"areas": func(info *cap.Info) []string {
+			var descs []string
+			for _, area := range info.Area {
+				if desc := strings.TrimSpace(area.AreaDesc); desc != "" {
+					descs = append(descs, desc)
+				}
+			}
+			return descs
+		},
+		"join": strings.Join,
+		"trim": strings.TrimSpace,
+		// md escapes Markdown formatting characters
+		"md": markdownEscaper.Replace,
+	}
+}
+
+// Funcs returns the helper functions available to templates, for use with
+// (*template.Template).Funcs before parsing:
+//
+//	label KEY        translated heading, such as label "severity"
+//	code VALUE       translated code value, such as code .Info.Severity
+//	datetime VALUE   time in the time zone of the options, or "" if unset
+//	areas INFO       descriptions of the areas of the Info
+//	join LIST SEP    strings.Join
+//	trim TEXT        strings.TrimSpace
+//	md TEXT          text with Markdown formatting characters escaped
+//
+// The functions are bound to the language of each Info by Execute.
+func Funcs(opts Options) template.FuncMap {
+	info := &cap.Info{}
+	return funcs(opts, info, labelLanguage(opts, info))
+}
+
+// Execute executes the template against the Info, and returns the result.
+// The template must have been parsed with Funcs.
+func Execute(tmpl *template.Template, info *cap.Info, opts Options) (string, error) {
+	language := labelLanguage(opts, info)
+	tmpl, err := tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+	var buff bytes.Buffer
+	err = tmpl.Funcs(funcs(opts, info, language)).Execute(&buff, Data{Info: info, Language: language})
+	if err != nil {
+		return "", err
+	}
+	return buff.String(), nil
+}
+
+// textTemplate renders an Info as plain text.
+var textTemplate = template.Must(template.New("text").Funcs(Funcs(Options{})).Parse(
+	`{{with trim .Info.Headline}}{{.}}
+{{end -}}
+{{label "event"}}: {{with trim .Info.Event}}{{.}}{{else}}{{label "unknown"}}{{end}}
+{{label "severity"}}: {{code .Info.Severity}} | {{label "urgency"}}: {{code .Info.Urgency}} | {{label "certainty"}}: {{code .Info.Certainty}}
+{{with datetime .Info.Effective}}{{label "effective"}}: {{.}}
+{{end -}}
+{{label "expires"}}: {{with datetime .Info.Expires}}{{.}}{{else}}{{label "untilNotice"}}{{end}}
+{{with areas .Info}}{{label "areas"}}: {{join . ", "}}
+{{end -}}
+{{with trim .Info.Description}}
+{{.}}
+{{end -}}
+{{with trim .Info.Instruction}}
+{{label "instruction"}}: {{.}}
+{{end -}}
+`))
+
+// markdownTemplate renders an Info as Markdown.
+var markdownTemplate = template.Must(template.New("markdown").Funcs(Funcs(Options{})).Parse(
+	`{{with trim .Info.Headline}}## {{md .}}
+
+{{end -}}
+- **{{label "event"}}:** {{with trim .Info.Event}}{{md .}}{{else}}{{label "unknown"}}{{end}}
+- **{{label "severity"}}:** {{code .Info.Severity | md}}
+- **{{label "urgency"}}:** {{code .Info.Urgency | md}}
+- **{{label "certainty"}}:** {{code .Info.Certainty | md}}
+{{with datetime .Info.Effective}}- **{{label "effective"}}:** {{.}}
+{{end -}}
+- **{{label "expires"}}:** {{with datetime .Info.Expires}}{{.}}{{else}}{{label "untilNotice"}}{{end}}
+{{with areas .Info}}- **{{label "areas"}}:** {{md (join . ", ")}}
+{{end -}}
+{{with trim .Info.Description}}
+{{md .}}
+{{end -}}
+{{with trim .Info.Instruction}}
+**{{label "instruction"}}:** {{md .}}
+{{end -}}
+`))
+
+// Text renders the Info as plain text.
+func Text(info *cap.Info, opts Options) (string, error) {
+	return Execute(textTemplate, info, opts)
+}
+
+// Markdown renders the Info as Markdown. Text from the Info is escaped, so
+// that it cannot introduce links or formatting.
+func Markdown(info *cap.Info, opts Options) (string, error) {
+	return Execute(markdownTemplate, info, opts)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package render_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/thetannerryan/cap"
+	"github.com/thetannerryan/cap/render"
+)
+
+// test is a helper for the tests.
+func test(t *testing.T, name, expected, actual string) {
+	fmt.Printf(">> Testing %s\nExpected: %s\nActual:   %s\n", name, expected, actual)
+	if expected != actual {
+		t.Errorf("Incorrect output")
+	}
+}
+
+// load parses one of the example alerts.
+func load(name string) *cap.Alert {
+	contents, err := ioutil.ReadFile("../testing/" + name)
+	if err != nil {
+		panic(err)
+	}
+	alert, err := cap.ParseCAP(contents)
+	if err != nil {
+		panic(err)
+	}
+	return alert
+}
+
+// TestText tests plain text rendering in a chosen time zone.
+func TestText(t *testing.T) {
+	thunderstorm := load("Oasis_ThunderstormWarning.xml")
+	pacific, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip(err)
+	}
+	text, err := render.Text(&thunderstorm.Info[0], render.Options{Location: pacific})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Text", `SEVERE THUNDERSTORM WARNING
+Event: SEVERE THUNDERSTORM
+Severity: Severe | Urgency: Immediate | Certainty: Observed
+Expires: 2003-06-17 16:00 PDT
+Areas: EXTREME NORTH CENTRAL TUOLUMNE COUNTY IN CALIFORNIA, EXTREME NORTHEASTERN CALAVERAS COUNTY IN CALIFORNIA, SOUTHWESTERN ALPINE COUNTY IN CALIFORNIA
+
+AT 254 PM PDT...NATIONAL WEATHER SERVICE DOPPLER RADAR INDICATED A SEVERE THUNDERSTORM OVER SOUTH CENTRAL ALPINE COUNTY...OR ABOUT 18 MILES SOUTHEAST OF KIRKWOOD...MOVING SOUTHWEST AT 5 MPH. HAIL...INTENSE RAIN AND STRONG DAMAGING WINDS ARE LIKELY WITH THIS STORM.
+
+Instructions: TAKE COVER IN A SUBSTANTIAL SHELTER UNTIL THE STORM PASSES.
+`, text)
+
+	text, err = render.Text(&cap.Info{Severity: cap.SeverityUnknown}, render.Options{Language: "es"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Text missing fields", `Evento: Desconocido
+Gravedad: Desconocida | Urgencia: Inmediata | Certeza: Observada
+Expira: hasta nuevo aviso
+`, text)
+}
+
+// TestMarkdown tests Markdown rendering with the labels of the Info language,
+// and that text from the Info is escaped.
+func TestMarkdown(t *testing.T) {
+	wind := load("PelmorexNAADS_WindWarning.xml")
+	info := wind.Info[0]
+	info.Description, info.Instruction = "", ""
+	info.Headline = "[click](http://example.org) *now*"
+	markdown, err := render.Markdown(&info, render.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	test(t, "Markdown", `## \[click\](http://example.org) \*now\*
+
+- **Événement:** vent
+- **Gravité:** Modérée
+- **Urgence:** Future
+- **Certitude:** Probable
+- **En vigueur:** 2019-01-09 02:16 UTC
+- **Expire:** 2019-01-09 18:16 UTC
+- **Régions:** Îles-de-la-Madeleine
+`, markdown)
+}
+
+// TestExecute tests a custom template using the helper functions.
+func TestExecute(t *testing.T) {
+	wind := load("PelmorexNAADS_WindWarning.xml")
+	opts := render.Options{}
+	tmpl := template.Must(template.New("sms").Funcs(render.Funcs(opts)).Parse(
+		`{{code .Info.Severity}} {{.Language}}: {{.Info.Headline}} ({{join (areas .Info) ", "}})`))
+
+	var out []string
+	for i := range wind.Info {
+		text, err := render.Execute(tmpl, &wind.Info[i], opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, text)
+	}
+	test(t, "Execute", "Modérée fr: avertissement de vent en vigueur (Îles-de-la-Madeleine); Moderate en: wind warning in effect (Îles-de-la-Madeleine)", strings.Join(out, "; "))
+}
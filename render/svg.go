@@ -0,0 +1,98 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"html"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/thetannerryan/cap"
+)
+
+// kmPerDegree is the length of a degree of latitude, in kilometers.
+const kmPerDegree = 111.195
+
+// mapWidth is the width of the area map, in CSS pixels.
+const mapWidth = 320
+
+// projection maps latitude / longitude onto the plane of the area map. It is
+// an equirectangular projection centered on the bounding box, so that shapes
+// keep their proportions near its center latitude.
+type projection struct {
+	bbox  cap.BBox
+	scale float64 // cosine of the center latitude
+}
+
+// x returns the horizontal coordinate of a longitude, in degrees of latitude
+// east of the bounding box. Longitudes are unwrapped across the antimeridian.
+func (p projection) x(lon float64) float64 {
+	return math.Mod(lon-p.bbox.MinLon+720, 360) * p.scale
+}
+
+// y returns the vertical coordinate of a latitude, in degrees south of the
+// bounding box.
+func (p projection) y(lat float64) float64 {
+	return p.bbox.MaxLat - lat
+}
+
+// coord formats a map coordinate.
+func coord(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
+
+// areaMap returns an inline SVG outlining the polygons and circles of the Info,
+// in the color, with the title read by assistive technology. If the Info has
+// no usable geometry, an empty string is returned.
+func areaMap(info *cap.Info, id, title, color string) template.HTML {
+	var polygons [][]cap.Point
+	var circles []cap.Circle
+	for i := range info.Area {
+		polygon, err := info.Area[i].PolygonPoints()
+		if err == nil && len(polygon) > 0 {
+			polygons = append(polygons, polygon)
+		}
+		areaCircles, err := info.Area[i].Circles()
+		if err == nil {
+			circles = append(circles, areaCircles...)
+		}
+	}
+	bbox, err := info.BBox()
+	if err != nil || bbox.Empty() || (len(polygons) == 0 && len(circles) == 0) {
+		return ""
+	}
+
+	p := projection{bbox: bbox, scale: math.Cos((bbox.MinLat + bbox.MaxLat) / 2 * math.Pi / 180)}
+	width := bbox.MaxLon - bbox.MinLon
+	if width < 0 {
+		width += 360
+	}
+	width *= p.scale
+	height := bbox.MaxLat - bbox.MinLat
+	pad := math.Max(math.Max(width, height)*0.05, 0.01)
+	width, height = width+2*pad, height+2*pad
+
+	var buff strings.Builder
+	buff.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" class="cap-map" role="img" aria-labelledby="` + id + `-map"`)
+	buff.WriteString(` width="` + strconv.Itoa(mapWidth) + `" height="` + strconv.Itoa(int(math.Ceil(mapWidth*height/width))) + `"`)
+	buff.WriteString(` viewBox="` + coord(-pad) + " " + coord(-pad) + " " + coord(width) + " " + coord(height) + `">`)
+	buff.WriteString(`<title id="` + id + `-map">` + html.EscapeString(title) + `</title>`)
+	buff.WriteString(`<g fill="` + color + `" fill-opacity="0.25" stroke="` + color + `" stroke-width="2" vector-effect="non-scaling-stroke">`)
+	for _, polygon := range polygons {
+		points := make([]string, len(polygon))
+		for i, point := range polygon {
+			points[i] = coord(p.x(point.Longitude)) + "," + coord(p.y(point.Latitude))
+		}
+		buff.WriteString(`<polygon vector-effect="non-scaling-stroke" points="` + strings.Join(points, " ") + `"/>`)
+	}
+	for _, circle := range circles {
+		buff.WriteString(`<circle vector-effect="non-scaling-stroke" cx="` + coord(p.x(circle.Center.Longitude)) +
+			`" cy="` + coord(p.y(circle.Center.Latitude)) + `" r="` + coord(circle.Radius/kmPerDegree) + `"/>`)
+	}
+	buff.WriteString(`</g></svg>`)
+	return template.HTML(buff.String())
+}
@@ -7,9 +7,11 @@ package cap
 import (
 	"encoding/json"
 	"encoding/xml"
-	"errors"
+	"strings"
 )
 
+//go:generate stringer -type=ResponseType -trimprefix=ResponseType
+
 // ResponseType is a code denoting the appropriate handling of the alert message
 type ResponseType int
 
@@ -58,37 +60,62 @@ var (
 func stringToResponseTypeCode(t *ResponseType, val string) error {
 	enum, ok := ResponseTypeMapping[val]
 	if !ok {
-		return errors.New("Error: illegal value " + val + " for ResponseType code")
+		return illegalValue(val, "ResponseType")
 	}
 	*t = enum
 	return nil
 }
 
-// String converts the ResponseType code back to a string.
-func (t ResponseType) String() string {
-	for key, val := range ResponseTypeMapping {
-		if val == t {
-			return key
+// foldResponseTypeCode will perform the mapping of string to a ResponseType
+// code without regard to case or surrounding whitespace.
+func foldResponseTypeCode(val string) (int, bool) {
+	val = strings.TrimSpace(val)
+	for key, enum := range ResponseTypeMapping {
+		if strings.EqualFold(key, val) {
+			return int(enum), true
 		}
 	}
-	// logically never reached
-	return ""
+	return 0, false
+}
+
+// code returns the number of the ResponseType code.
+func (t ResponseType) code() int {
+	return int(t)
+}
+
+// validate returns an error if the ResponseType is not one of the defined
+// codes.
+func (t ResponseType) validate() error {
+	if t < 0 || int(t) >= len(_ResponseType_index)-1 {
+		return illegalValue(t.String(), "ResponseType")
+	}
+	return nil
 }
 
 // UnmarshalXML will be used during the XML unmarshaling for conversion to
 // ResponseType code.
 func (t *ResponseType) UnmarshalXML(decoder *xml.Decoder, elem xml.StartElement) error {
+	offset := decoder.InputOffset()
 	var val string
 	if err := decoder.DecodeElement(&val, &elem); err != nil {
 		return err
 	}
-	return stringToResponseTypeCode(t, val)
+	err := stringToResponseTypeCode(t, val)
+	if err == nil {
+		return nil
+	}
+	code, ok := lenientCode(decoder, elem, offset, val, foldResponseTypeCode)
+	if !ok {
+		return atOffset(err, offset)
+	}
+	*t = ResponseType(code)
+	return nil
 }
 
 // MarshalXML converts the ResponseType code back to a string when marshaling
 // XML.
 func (t ResponseType) MarshalXML(encoder *xml.Encoder, elem xml.StartElement) error {
-	return encoder.EncodeElement(t.String(), elem)
+	return marshalCode(encoder, elem, t)
 }
 
 // UnmarshalJSON will be used during the JSON unmarshaling for conversion to
@@ -104,5 +131,8 @@ func (t *ResponseType) UnmarshalJSON(buff []byte) error {
 // MarshalJSON converts the ResponseType code back to a string when marshaling
 // JSON.
 func (t ResponseType) MarshalJSON() ([]byte, error) {
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
 	return json.Marshal(t.String())
 }
@@ -7,9 +7,11 @@ package cap
 import (
 	"encoding/json"
 	"encoding/xml"
-	"errors"
+	"strings"
 )
 
+//go:generate stringer -type=Scope -trimprefix=Scope
+
 // Scope is a code denoting the appropriate handling of the alert message
 type Scope int
 
@@ -38,36 +40,60 @@ var (
 func stringToScopeCode(t *Scope, val string) error {
 	enum, ok := ScopeMapping[val]
 	if !ok {
-		return errors.New("Error: illegal value " + val + " for Scope code")
+		return illegalValue(val, "Scope")
 	}
 	*t = enum
 	return nil
 }
 
-// String converts the Scope code back to a string.
-func (t Scope) String() string {
-	for key, val := range ScopeMapping {
-		if val == t {
-			return key
+// foldScopeCode will perform the mapping of string to a Scope code without
+// regard to case or surrounding whitespace.
+func foldScopeCode(val string) (int, bool) {
+	val = strings.TrimSpace(val)
+	for key, enum := range ScopeMapping {
+		if strings.EqualFold(key, val) {
+			return int(enum), true
 		}
 	}
-	// logically never reached
-	return ""
+	return 0, false
+}
+
+// code returns the number of the Scope code.
+func (t Scope) code() int {
+	return int(t)
+}
+
+// validate returns an error if the Scope is not one of the defined codes.
+func (t Scope) validate() error {
+	if t < 0 || int(t) >= len(_Scope_index)-1 {
+		return illegalValue(t.String(), "Scope")
+	}
+	return nil
 }
 
 // UnmarshalXML will be used during the XML unmarshaling for conversion to Scope
 // code.
 func (t *Scope) UnmarshalXML(decoder *xml.Decoder, elem xml.StartElement) error {
+	offset := decoder.InputOffset()
 	var val string
 	if err := decoder.DecodeElement(&val, &elem); err != nil {
 		return err
 	}
-	return stringToScopeCode(t, val)
+	err := stringToScopeCode(t, val)
+	if err == nil {
+		return nil
+	}
+	code, ok := lenientCode(decoder, elem, offset, val, foldScopeCode)
+	if !ok {
+		return atOffset(err, offset)
+	}
+	*t = Scope(code)
+	return nil
 }
 
 // MarshalXML converts the Scope code back to a string when marshaling XML.
 func (t Scope) MarshalXML(encoder *xml.Encoder, elem xml.StartElement) error {
-	return encoder.EncodeElement(t.String(), elem)
+	return marshalCode(encoder, elem, t)
 }
 
 // UnmarshalJSON will be used during the JSON unmarshaling for conversion to
@@ -82,5 +108,8 @@ func (t *Scope) UnmarshalJSON(buff []byte) error {
 
 // MarshalJSON converts the Scope code back to a string when marshaling JSON.
 func (t Scope) MarshalJSON() ([]byte, error) {
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
 	return json.Marshal(t.String())
 }
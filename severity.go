@@ -7,10 +7,16 @@ package cap
 import (
 	"encoding/json"
 	"encoding/xml"
-	"errors"
+	"strings"
 )
 
-// Severity is a code denoting the appropriate handling of the alert message
+//go:generate stringer -type=Severity -trimprefix=Severity
+
+// Severity is a code denoting the appropriate handling of the alert message.
+//
+// Severity codes are declared from most to least severe, followed by
+// SeverityUnknown. Use Rank or Compare to order them, rather than the
+// underlying value.
 type Severity int
 
 const (
@@ -42,36 +48,89 @@ var (
 func stringToSeverityCode(t *Severity, val string) error {
 	enum, ok := SeverityMapping[val]
 	if !ok {
-		return errors.New("Error: illegal value " + val + " for Severity code")
+		return illegalValue(val, "Severity")
 	}
 	*t = enum
 	return nil
 }
 
-// String converts the Severity code back to a string.
-func (t Severity) String() string {
-	for key, val := range SeverityMapping {
-		if val == t {
-			return key
+// foldSeverityCode will perform the mapping of string to a Severity code
+// without regard to case or surrounding whitespace.
+func foldSeverityCode(val string) (int, bool) {
+	val = strings.TrimSpace(val)
+	for key, enum := range SeverityMapping {
+		if strings.EqualFold(key, val) {
+			return int(enum), true
 		}
 	}
-	// logically never reached
-	return ""
+	return 0, false
+}
+
+// code returns the number of the Severity code.
+func (t Severity) code() int {
+	return int(t)
+}
+
+// validate returns an error if the Severity is not one of the defined codes.
+func (t Severity) validate() error {
+	if t < 0 || int(t) >= len(_Severity_index)-1 {
+		return illegalValue(t.String(), "Severity")
+	}
+	return nil
+}
+
+// Rank returns the importance of the Severity code, from 4 for SeverityExtreme
+// down to 1 for SeverityMinor. SeverityUnknown, and any value outside of the
+// defined codes, ranks lowest at 0.
+func (t Severity) Rank() int {
+	switch t {
+	case SeverityExtreme:
+		return 4
+	case SeveritySevere:
+		return 3
+	case SeverityModerate:
+		return 2
+	case SeverityMinor:
+		return 1
+	}
+	return 0
+}
+
+// Compare returns 1 if the Severity code is more severe than the other, -1 if
+// it is less severe, and 0 if they rank equally.
+func (t Severity) Compare(other Severity) int {
+	switch {
+	case t.Rank() > other.Rank():
+		return 1
+	case t.Rank() < other.Rank():
+		return -1
+	}
+	return 0
 }
 
 // UnmarshalXML will be used during the XML unmarshaling for conversion to
 // Severity code.
 func (t *Severity) UnmarshalXML(decoder *xml.Decoder, elem xml.StartElement) error {
+	offset := decoder.InputOffset()
 	var val string
 	if err := decoder.DecodeElement(&val, &elem); err != nil {
 		return err
 	}
-	return stringToSeverityCode(t, val)
+	err := stringToSeverityCode(t, val)
+	if err == nil {
+		return nil
+	}
+	code, ok := lenientCode(decoder, elem, offset, val, foldSeverityCode)
+	if !ok {
+		return atOffset(err, offset)
+	}
+	*t = Severity(code)
+	return nil
 }
 
 // MarshalXML converts the Severity code back to a string when marshaling XML.
 func (t Severity) MarshalXML(encoder *xml.Encoder, elem xml.StartElement) error {
-	return encoder.EncodeElement(t.String(), elem)
+	return marshalCode(encoder, elem, t)
 }
 
 // UnmarshalJSON will be used during the JSON unmarshaling for conversion to
@@ -86,5 +145,8 @@ func (t *Severity) UnmarshalJSON(buff []byte) error {
 
 // MarshalJSON converts the Severity code back to a string when marshaling JSON.
 func (t Severity) MarshalJSON() ([]byte, error) {
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
 	return json.Marshal(t.String())
 }
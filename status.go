@@ -7,9 +7,11 @@ package cap
 import (
 	"encoding/json"
 	"encoding/xml"
-	"errors"
+	"strings"
 )
 
+//go:generate stringer -type=Status -trimprefix=Status
+
 // Status is a code denoting the appropriate handling of the alert message
 type Status int
 
@@ -45,36 +47,60 @@ var (
 func stringToStatusCode(t *Status, val string) error {
 	enum, ok := StatusMapping[val]
 	if !ok {
-		return errors.New("Error: illegal value " + val + " for Status code")
+		return illegalValue(val, "Status")
 	}
 	*t = enum
 	return nil
 }
 
-// String converts the Status code back to a string.
-func (t Status) String() string {
-	for key, val := range StatusMapping {
-		if val == t {
-			return key
+// foldStatusCode will perform the mapping of string to a Status code without
+// regard to case or surrounding whitespace.
+func foldStatusCode(val string) (int, bool) {
+	val = strings.TrimSpace(val)
+	for key, enum := range StatusMapping {
+		if strings.EqualFold(key, val) {
+			return int(enum), true
 		}
 	}
-	// logically never reached
-	return ""
+	return 0, false
+}
+
+// code returns the number of the Status code.
+func (t Status) code() int {
+	return int(t)
+}
+
+// validate returns an error if the Status is not one of the defined codes.
+func (t Status) validate() error {
+	if t < 0 || int(t) >= len(_Status_index)-1 {
+		return illegalValue(t.String(), "Status")
+	}
+	return nil
 }
 
 // UnmarshalXML will be used during the XML unmarshaling for conversion to
 // Status code.
 func (t *Status) UnmarshalXML(decoder *xml.Decoder, elem xml.StartElement) error {
+	offset := decoder.InputOffset()
 	var val string
 	if err := decoder.DecodeElement(&val, &elem); err != nil {
 		return err
 	}
-	return stringToStatusCode(t, val)
+	err := stringToStatusCode(t, val)
+	if err == nil {
+		return nil
+	}
+	code, ok := lenientCode(decoder, elem, offset, val, foldStatusCode)
+	if !ok {
+		return atOffset(err, offset)
+	}
+	*t = Status(code)
+	return nil
 }
 
 // MarshalXML converts the Status code back to a string when marshaling XML.
 func (t Status) MarshalXML(encoder *xml.Encoder, elem xml.StartElement) error {
-	return encoder.EncodeElement(t.String(), elem)
+	return marshalCode(encoder, elem, t)
 }
 
 // UnmarshalJSON will be used during the JSON unmarshaling for conversion to
@@ -89,5 +115,8 @@ func (t *Status) UnmarshalJSON(buff []byte) error {
 
 // MarshalJSON converts the Status code back to a string when marshaling JSON.
 func (t Status) MarshalJSON() ([]byte, error) {
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
 	return json.Marshal(t.String())
 }
@@ -7,10 +7,16 @@ package cap
 import (
 	"encoding/json"
 	"encoding/xml"
-	"errors"
+	"strings"
 )
 
-// Urgency is a code denoting the appropriate handling of the alert message
+//go:generate stringer -type=Urgency -trimprefix=Urgency
+
+// Urgency is a code denoting the appropriate handling of the alert message.
+//
+// Urgency codes are declared from most to least urgent, followed by
+// UrgencyUnknown. Use Rank or Compare to order them, rather than the underlying
+// value.
 type Urgency int
 
 const (
@@ -44,36 +50,89 @@ var (
 func stringToUrgencyCode(t *Urgency, val string) error {
 	enum, ok := UrgencyMapping[val]
 	if !ok {
-		return errors.New("Error: illegal value " + val + " for Urgency code")
+		return illegalValue(val, "Urgency")
 	}
 	*t = enum
 	return nil
 }
 
-// String converts the Urgency code back to a string.
-func (t Urgency) String() string {
-	for key, val := range UrgencyMapping {
-		if val == t {
-			return key
+// foldUrgencyCode will perform the mapping of string to a Urgency code without
+// regard to case or surrounding whitespace.
+func foldUrgencyCode(val string) (int, bool) {
+	val = strings.TrimSpace(val)
+	for key, enum := range UrgencyMapping {
+		if strings.EqualFold(key, val) {
+			return int(enum), true
 		}
 	}
-	// logically never reached
-	return ""
+	return 0, false
+}
+
+// code returns the number of the Urgency code.
+func (t Urgency) code() int {
+	return int(t)
+}
+
+// validate returns an error if the Urgency is not one of the defined codes.
+func (t Urgency) validate() error {
+	if t < 0 || int(t) >= len(_Urgency_index)-1 {
+		return illegalValue(t.String(), "Urgency")
+	}
+	return nil
+}
+
+// Rank returns the importance of the Urgency code, from 4 for UrgencyImmediate
+// down to 1 for UrgencyPast. UrgencyUnknown, and any value outside of the
+// defined codes, ranks lowest at 0.
+func (t Urgency) Rank() int {
+	switch t {
+	case UrgencyImmediate:
+		return 4
+	case UrgencyExpected:
+		return 3
+	case UrgencyFuture:
+		return 2
+	case UrgencyPast:
+		return 1
+	}
+	return 0
+}
+
+// Compare returns 1 if the Urgency code is more urgent than the other, -1 if it
+// is less urgent, and 0 if they rank equally.
+func (t Urgency) Compare(other Urgency) int {
+	switch {
+	case t.Rank() > other.Rank():
+		return 1
+	case t.Rank() < other.Rank():
+		return -1
+	}
+	return 0
 }
 
 // UnmarshalXML will be used during the XML unmarshaling for conversion to
 // Urgency code.
 func (t *Urgency) UnmarshalXML(decoder *xml.Decoder, elem xml.StartElement) error {
+	offset := decoder.InputOffset()
 	var val string
 	if err := decoder.DecodeElement(&val, &elem); err != nil {
 		return err
 	}
-	return stringToUrgencyCode(t, val)
+	err := stringToUrgencyCode(t, val)
+	if err == nil {
+		return nil
+	}
+	code, ok := lenientCode(decoder, elem, offset, val, foldUrgencyCode)
+	if !ok {
+		return atOffset(err, offset)
+	}
+	*t = Urgency(code)
+	return nil
 }
 
 // MarshalXML converts the Urgency code back to a string when marshaling XML.
 func (t Urgency) MarshalXML(encoder *xml.Encoder, elem xml.StartElement) error {
-	return encoder.EncodeElement(t.String(), elem)
+	return marshalCode(encoder, elem, t)
 }
 
 // UnmarshalJSON will be used during the JSON unmarshaling for conversion to
@@ -88,5 +147,8 @@ func (t *Urgency) UnmarshalJSON(buff []byte) error {
 
 // MarshalJSON converts the Urgency code back to a string when marshaling JSON.
 func (t Urgency) MarshalJSON() ([]byte, error) {
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
 	return json.Marshal(t.String())
 }
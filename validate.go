@@ -0,0 +1,165 @@
+// Copyright (c) 2019 Tanner Ryan. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cap
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ValidationError describes an element of an Alert that does not conform to
+// CAP 1.2.
+type ValidationError struct {
+	Path string // Slash separated element path, such as alert/info[2]/event
+	Msg  string // Description of the problem
+}
+
+// Error returns the problem and the path of the element.
+func (e *ValidationError) Error() string {
+	return "Error: " + e.Msg + " at " + e.Path
+}
+
+// ValidationErrors is the list of problems found by Validate.
+type ValidationErrors []*ValidationError
+
+// Error returns the problems, separated by semicolons.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// validator collects the problems found while validating an Alert.
+type validator struct {
+	errs  ValidationErrors
+	unset map[string]bool // Paths of the required codes never set by a builder
+}
+
+// fail records a problem with the element at the path.
+func (v *validator) fail(path, msg string) {
+	v.errs = append(v.errs, &ValidationError{Path: path, Msg: msg})
+}
+
+// required records a problem if the text of a required element is empty.
+func (v *validator) required(path, val string) {
+	if strings.TrimSpace(val) == "" {
+		v.fail(path, "missing required element")
+	}
+}
+
+// identifier records a problem if an identifier or sender is missing or
+// contains forbidden characters.
+func (v *validator) identifier(path, val string) {
+	if val == "" {
+		v.fail(path, "missing required element")
+		return
+	}
+	v.check(path, ValidateIdentifier(val))
+}
+
+// check records the error of a code or value, if any.
+func (v *validator) check(path string, err error) {
+	if err != nil {
+		v.fail(path, strings.TrimPrefix(err.Error(), "Error: "))
+	}
+}
+
+// code records a problem if the code was never set, or is neither one of the
+// defined codes nor a code kept by lenient parsing within the texts of the
+// Alert or Info holding it.
+func (v *validator) code(path string, code Code, texts []string) {
+	if v.unset[path] {
+		v.fail(path, "missing required element")
+		return
+	}
+	if _, ok := keptText(texts, code); ok {
+		return
+	}
+	v.check(path, code.validate())
+}
+
+// index returns the path of the nth (from 0) repeated element.
+func index(path, name string, n int) string {
+	return path + "/" + name + "[" + strconv.Itoa(n+1) + "]"
+}
+
+// Validate checks that the Alert contains the elements required by CAP 1.2, and
+// that its codes, times and geometry are well formed. If any problems are
+// found, they are returned as ValidationErrors.
+func (t *Alert) Validate() error {
+	return t.validate(&validator{})
+}
+
+// validate checks the Alert and its Info blocks, returning the problems
+// collected by the validator.
+func (t *Alert) validate(v *validator) error {
+	v.identifier("alert/identifier", t.Identifier)
+	v.identifier("alert/sender", t.Sender)
+	if t.Sent.Time().IsZero() {
+		v.fail("alert/sent", "missing required element")
+	}
+	v.code("alert/status", t.Status, t.Unrecognized)
+	v.code("alert/msgType", t.MsgType, t.Unrecognized)
+	v.code("alert/scope", t.Scope, t.Unrecognized)
+	if t.Scope == ScopeRestricted {
+		v.required("alert/restriction", t.Restriction)
+	}
+	if t.Scope == ScopePrivate && t.Addresses.Len() == 0 {
+		v.fail("alert/addresses", "missing required element")
+	}
+	for i := range t.Info {
+		t.Info[i].validate(v, index("alert", "info", i))
+	}
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+// validate checks the Info and its Resource and Area blocks.
+func (t *Info) validate(v *validator, path string) {
+	if len(t.Category) == 0 {
+		v.fail(path+"/category", "missing required element")
+	}
+	for i, category := range t.Category {
+		v.code(index(path, "category", i), category, t.Unrecognized)
+	}
+	v.required(path+"/event", t.Event)
+	for i, responseType := range t.ResponseType {
+		v.code(index(path, "responseType", i), responseType, t.Unrecognized)
+	}
+	v.code(path+"/urgency", t.Urgency, t.Unrecognized)
+	v.code(path+"/severity", t.Severity, t.Unrecognized)
+	v.code(path+"/certainty", t.Certainty, t.Unrecognized)
+	for i := range t.Resource {
+		resource := &t.Resource[i]
+		resourcePath := index(path, "resource", i)
+		v.required(resourcePath+"/resourceDesc", resource.ResourceDesc)
+		v.required(resourcePath+"/mimeType", resource.MimeType)
+	}
+	for i := range t.Area {
+		t.Area[i].validate(v, index(path, "area", i))
+	}
+}
+
+// validate checks the Area and its geometry.
+func (a *Area) validate(v *validator, path string) {
+	v.required(path+"/areaDesc", a.AreaDesc)
+	points, err := a.PolygonPoints()
+	v.check(path+"/polygon[1]", err)
+	if err == nil && len(points) > 0 {
+		if len(points) < 4 {
+			v.fail(path+"/polygon[1]", "polygon requires at least four points")
+		} else if points[0] != points[len(points)-1] {
+			v.fail(path+"/polygon[1]", "polygon is not closed")
+		}
+	}
+	for i, val := range a.Circle {
+		_, err := parseCircle(val)
+		v.check(index(path, "circle", i), err)
+	}
+}